@@ -0,0 +1,284 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+	mcpgolang "github.com/metoro-io/mcp-golang"
+)
+
+// actionWaitTimeout is the maximum time spent waiting for actions to finish.
+var actionWaitTimeout = 10 * time.Minute
+
+// ActionReadByIDArgs represents the arguments required to read an Action by ID.
+// It contains the Action ID that is needed to perform the lookup.
+type ActionReadByIDArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The action id to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// ActionListArgs represents the arguments for listing Actions.
+// Actions can be filtered by the type and id of the resource they relate to and by their status.
+// Actions have no labels, so the label selector of the list arguments is not supported.
+type ActionListArgs struct {
+	ResourceType string   `json:"resource_type,omitempty" jsonschema:"description=Only return actions of this resource type (server/volume/image/floating_ip/primary_ip/network/firewall/load_balancer/certificate)"`
+	ResourceID   int64    `json:"resource_id,omitempty" jsonschema:"description=Only return actions relating to the resource with this id; requires resource_type"`
+	Status       []string `json:"status,omitempty" jsonschema:"description=Only return actions with one of these statuses (running/success/error)"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// ActionWaitArgs represents the arguments required to wait for Actions.
+type ActionWaitArgs struct {
+	IDs []int64 `json:"ids" jsonschema:"required,description=The action ids to wait for"`
+	ProjectArgs
+	OutputArgs
+}
+
+// ActionResource represents a resource an Action is related to.
+type ActionResource struct {
+	ID   int64  `json:"id" jsonschema:"required,description=The id of the resource"`
+	Type string `json:"type" jsonschema:"required,description=The type of the resource, e.g. server or volume"`
+}
+
+type ActionResponse struct {
+	ID           int64            `json:"id" jsonschema:"required,description=Unique identifier of the action"`
+	Command      string           `json:"command" jsonschema:"required,description=The command executed by the action, e.g. start_server"`
+	Status       string           `json:"status" jsonschema:"required,description=Status of the action, either of running, success or error"`
+	Progress     int              `json:"progress" jsonschema:"required,description=Progress of the action in percent"`
+	Started      time.Time        `json:"started" jsonschema:"required,description=Timestamp of when the action was started"`
+	Finished     *time.Time       `json:"finished,omitempty" jsonschema:"description=Timestamp of when the action was finished"`
+	ErrorCode    string           `json:"error_code,omitempty" jsonschema:"description=The error code if the action failed"`
+	ErrorMessage string           `json:"error_message,omitempty" jsonschema:"description=The error message if the action failed"`
+	Resources    []ActionResource `json:"resources" jsonschema:"description=Resources the action relates to"`
+}
+
+func toActionResponse(a *hcloud.Action) *ActionResponse {
+	if a == nil {
+		return nil
+	}
+
+	resources := make([]ActionResource, 0, len(a.Resources))
+	for _, r := range a.Resources {
+		if r != nil {
+			resources = append(resources, ActionResource{
+				ID:   r.ID,
+				Type: string(r.Type),
+			})
+		}
+	}
+
+	var finished *time.Time
+	if !a.Finished.IsZero() {
+		finished = &a.Finished
+	}
+
+	return &ActionResponse{
+		ID:           a.ID,
+		Command:      a.Command,
+		Status:       string(a.Status),
+		Progress:     a.Progress,
+		Started:      a.Started,
+		Finished:     finished,
+		ErrorCode:    a.ErrorCode,
+		ErrorMessage: a.ErrorMessage,
+		Resources:    resources,
+	}
+}
+
+func toActionResponseList(actions []*hcloud.Action) []*ActionResponse {
+	result := make([]*ActionResponse, 0, len(actions))
+	for _, a := range actions {
+		if a != nil {
+			result = append(result, toActionResponse(a))
+		}
+	}
+	return result
+}
+
+// resourceActionClients returns the action clients of every resource type that supports actions.
+func resourceActionClients(ctx context.Context) map[string]*hcloud.ResourceActionClient {
+	client := hcloudClient(ctx)
+	return map[string]*hcloud.ResourceActionClient{
+		"server":        client.Server.Action,
+		"volume":        client.Volume.Action,
+		"image":         client.Image.Action,
+		"floating_ip":   client.FloatingIP.Action,
+		"primary_ip":    client.PrimaryIP.Action,
+		"network":       client.Network.Action,
+		"firewall":      client.Firewall.Action,
+		"load_balancer": client.LoadBalancer.Action,
+		"certificate":   client.Certificate.Action,
+	}
+}
+
+// listActions returns a page of Actions, newest first. Actions of a single resource are listed with the
+// actions endpoint of the resource, which hcloud-go does not provide.
+func listActions(ctx context.Context, args ActionListArgs) ([]*hcloud.Action, *hcloud.Response, error) {
+	listOpts, err := args.listOpts()
+	if err != nil {
+		return nil, nil, err
+	}
+	if args.LabelSelector != EmptyString {
+		return nil, nil, fmt.Errorf("actions have no labels, label_selector is not supported")
+	}
+	// Actions are never filtered by the label scope
+	listOpts.LabelSelector = EmptyString
+	opts := hcloud.ActionListOpts{ListOpts: listOpts, Sort: []string{"id:desc"}}
+	for _, status := range args.Status {
+		opts.Status = append(opts.Status, hcloud.ActionStatus(status))
+	}
+
+	if args.ResourceType == EmptyString {
+		if args.ResourceID != 0 {
+			return nil, nil, fmt.Errorf("resource_id requires resource_type")
+		}
+		return hcloudClient(ctx).Action.List(ctx, opts)
+	}
+	actionClient, ok := resourceActionClients(ctx)[args.ResourceType]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported resource type: %s", args.ResourceType)
+	}
+	if args.ResourceID == 0 {
+		return actionClient.List(ctx, opts)
+	}
+
+	query := url.Values{}
+	if opts.Page > 0 {
+		query.Set("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage > 0 {
+		query.Set("per_page", strconv.Itoa(opts.PerPage))
+	}
+	for _, status := range opts.Status {
+		query.Add("status", string(status))
+	}
+	query.Set("sort", "id:desc")
+	// The endpoints of all resource types are the plural of the type, e.g. /load_balancers/{id}/actions
+	path := fmt.Sprintf("/%ss/%d/actions?%s", args.ResourceType, args.ResourceID, query.Encode())
+	req, err := hcloudClient(ctx).NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var body schema.ActionListResponse
+	resp, err := hcloudClient(ctx).Do(req, &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	actions := make([]*hcloud.Action, 0, len(body.Actions))
+	for _, action := range body.Actions {
+		actions = append(actions, hcloud.ActionFromSchema(action))
+	}
+	return actions, resp, nil
+}
+
+// waitForActions waits until all given actions are finished and returns their final state.
+// If one of the actions fails, its error is returned.
+func waitForActions(ctx context.Context, actions ...*hcloud.Action) ([]*hcloud.Action, error) {
+	ctx, cancel := context.WithTimeout(ctx, actionWaitTimeout)
+	defer cancel()
+
+	finished := make(map[int64]*hcloud.Action, len(actions))
+	err := hcloudClient(ctx).Action.WaitForFunc(ctx, func(update *hcloud.Action) error {
+		if update.Status != hcloud.ActionStatusRunning {
+			finished[update.ID] = update
+		}
+		if update.Status == hcloud.ActionStatusError {
+			return update.Error()
+		}
+		return nil
+	}, actions...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*hcloud.Action, 0, len(actions))
+	for _, a := range actions {
+		if a == nil {
+			continue
+		}
+		if update, ok := finished[a.ID]; ok {
+			result = append(result, update)
+		} else {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+// waitForAction waits until the given action is finished and returns its final state.
+func waitForAction(ctx context.Context, action *hcloud.Action) (*hcloud.Action, error) {
+	if action == nil {
+		return nil, nil
+	}
+	result, err := waitForActions(ctx, action)
+	if err != nil {
+		return nil, err
+	}
+	return result[0], nil
+}
+
+// ActionTools
+var actionTools = []Tool{
+	{
+		Name:        "get_an_action_by_id",
+		Description: "Retrieves an Action by its ID. Actions show the progress of asynchronous tasks like starting a server. If the Action does not exist, nil is returned.",
+		Handler: func(ctx context.Context, args ActionReadByIDArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ActionResponse, error) {
+				result, _, err := hcloudClient(ctx).Action.GetByID(ctx, args.ID)
+				if err != nil {
+					return nil, err
+				}
+				return toActionResponse(result), nil
+			})
+		},
+		Restriction: RestrictionReadOnly,
+	},
+	{
+		Name:        "get_all_actions",
+		Description: "Returns a page of Actions, newest first. Actions can be filtered by resource type, resource id and status.",
+		Handler: func(ctx context.Context, args ActionListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*ActionResponse], error) {
+				result, resp, err := listActions(ctx, args)
+				if err != nil {
+					return nil, err
+				}
+				return newListResponse(toActionResponseList(result), resp), nil
+			})
+		},
+		Restriction: RestrictionReadOnly,
+	},
+	{
+		Name:        "wait_for_action",
+		Description: "Waits until all given Actions are finished and returns their final state. Fails if one of the Actions fails.",
+		Handler: func(ctx context.Context, args ActionWaitArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() ([]*ActionResponse, error) {
+				actions := make([]*hcloud.Action, 0, len(args.IDs))
+				for _, id := range args.IDs {
+					action, _, err := hcloudClient(ctx).Action.GetByID(ctx, id)
+					if err != nil {
+						return nil, err
+					}
+					if action == nil {
+						return nil, fmt.Errorf("action %d not found", id)
+					}
+					actions = append(actions, action)
+				}
+				result, err := waitForActions(ctx, actions...)
+				if err != nil {
+					return nil, err
+				}
+				return toActionResponseList(result), nil
+			})
+		},
+		Restriction: RestrictionReadOnly,
+	},
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// AuditOutcomeSuccess and AuditOutcomeError are the possible outcomes of a tool invocation.
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeError   = "error"
+)
+
+// AuditStderr is the audit log path which writes the audit log to stderr.
+const AuditStderr = "stderr"
+
+// redactedValue replaces the values of secret arguments in the audit log.
+const redactedValue = "[REDACTED]"
+
+// secretArgumentNames lists the argument names, or parts of them, whose values are never written to the audit log.
+var secretArgumentNames = []string{"user_data", "password", "private_key", "token", "secret"}
+
+// AuditRecord represents a single tool invocation in the audit log.
+type AuditRecord struct {
+	Time        time.Time      `json:"time"`
+	Tool        string         `json:"tool"`
+	Arguments   map[string]any `json:"arguments"`
+	Principal   string         `json:"principal,omitempty"`
+	Project     string         `json:"project,omitempty"`
+	Restriction Restriction    `json:"restriction"`
+	DryRun      bool           `json:"dry_run"`
+	Outcome     string         `json:"outcome"`
+	Error       string         `json:"error,omitempty"`
+	ActionIDs   []int64        `json:"action_ids,omitempty"`
+	DurationMS  int64          `json:"duration_ms"`
+}
+
+// AuditLog is an append-only log of tool invocations written as JSON Lines.
+// Log files are rotated once they exceed the maximum size, records are never deleted before they are rotated
+// out of the backups.
+type AuditLog struct {
+	mu         sync.Mutex
+	path       string
+	out        io.Writer
+	file       *os.File
+	size       int64
+	maxSize    int64
+	maxBackups int
+}
+
+// auditLog is the audit log of the server, nil if auditing is disabled.
+var auditLog *AuditLog
+
+// newAuditLog opens the audit log at the given path, or writes to stderr if the path is AuditStderr.
+// A maximum size of 0 disables rotation, otherwise at least one backup is required.
+func newAuditLog(path string, maxSizeMB int, maxBackups int) (*AuditLog, error) {
+	if path == AuditStderr {
+		return &AuditLog{out: os.Stderr}, nil
+	}
+	if maxSizeMB > 0 && maxBackups < 1 {
+		return nil, fmt.Errorf("audit log rotation requires at least one backup")
+	}
+
+	l := &AuditLog{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxBackups: maxBackups,
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+func (l *AuditLog) open() error {
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to open audit log: %w", err)
+	}
+	l.file, l.out, l.size = file, file, info.Size()
+	return nil
+}
+
+// rotate moves the current log file to <path>.1, shifting older backups up to <path>.<maxBackups>.
+// The log file is reopened even if it could not be moved, so that records are still appended to it.
+func (l *AuditLog) rotate() error {
+	closeErr := l.file.Close()
+	l.file, l.out = nil, nil
+
+	var renameErr error
+	if closeErr == nil {
+		_ = os.Remove(fmt.Sprintf("%s.%d", l.path, l.maxBackups))
+		for i := l.maxBackups - 1; i >= 1; i-- {
+			_ = os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+		}
+		renameErr = os.Rename(l.path, l.path+".1")
+	}
+	return errors.Join(closeErr, renameErr, l.open())
+}
+
+// Check reopens the log file if it was closed by a failed rotation and reports whether records can be written.
+func (l *AuditLog) Check() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.reopen()
+}
+
+func (l *AuditLog) reopen() error {
+	if l.out != nil {
+		return nil
+	}
+	return l.open()
+}
+
+// Write appends the record to the audit log.
+func (l *AuditLog) Write(record *AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit record: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file != nil && l.maxSize > 0 && l.size > 0 && l.size+int64(len(line)) > l.maxSize {
+		if err := l.rotate(); err != nil {
+			// The record is still written if the log file could be reopened
+			log.Printf("Failed to rotate audit log: %v", err)
+		}
+	}
+	if err := l.reopen(); err != nil {
+		return err
+	}
+
+	n, err := l.out.Write(line)
+	l.size += int64(n)
+	return err
+}
+
+// Close closes the audit log file.
+func (l *AuditLog) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file, l.out = nil, nil
+	return err
+}
+
+type auditRecordKey struct{}
+
+// recordActions adds the IDs of the actions triggered by a tool to its audit record.
+func recordActions(ctx context.Context, actions ...*hcloud.Action) {
+	record, ok := ctx.Value(auditRecordKey{}).(*AuditRecord)
+	if !ok {
+		return
+	}
+	for _, action := range actions {
+		if action != nil {
+			record.ActionIDs = append(record.ActionIDs, action.ID)
+		}
+	}
+}
+
+// redactArguments converts tool arguments to a map and replaces the values of secret arguments.
+func redactArguments(args any) map[string]any {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil
+	}
+	var arguments map[string]any
+	if err := json.Unmarshal(data, &arguments); err != nil {
+		return nil
+	}
+	redactValue(arguments)
+	return arguments
+}
+
+func redactValue(value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if isSecretArgument(key) {
+				v[key] = redactedValue
+			} else {
+				redactValue(item)
+			}
+		}
+	case []any:
+		for _, item := range v {
+			redactValue(item)
+		}
+	}
+}
+
+func isSecretArgument(name string) bool {
+	for _, secret := range secretArgumentNames {
+		if strings.Contains(name, secret) {
+			return true
+		}
+	}
+	return false
+}
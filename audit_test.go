@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// countLines returns the number of lines of the file, 0 if it does not exist.
+func countLines(t *testing.T, path string) int {
+	t.Helper()
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return 0
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	lines := 0
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines++
+	}
+	return lines
+}
+
+func TestAuditLogRotate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := newAuditLog(path, 1, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	// Rotate after every record
+	l.maxSize = 1
+
+	for i := 0; i < 5; i++ {
+		if err := l.Write(&AuditRecord{Tool: "get_all_servers"}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	for _, file := range []string{path, path + ".1", path + ".2"} {
+		if got := countLines(t, file); got != 1 {
+			t.Errorf("%s has %d records, want 1", filepath.Base(file), got)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("backup beyond the maximum backups exists: %v", err)
+	}
+}
+
+func TestAuditLogRotateFailure(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l, err := newAuditLog(path, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	l.maxSize = 1
+
+	// A non-empty directory in place of the backup cannot be replaced by the log file
+	if err := os.MkdirAll(filepath.Join(path+".1", "blocked"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := l.Write(&AuditRecord{Tool: "get_all_servers"}); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	if got := countLines(t, path); got != 3 {
+		t.Errorf("audit log has %d records, want all 3 records appended after the failed rotations", got)
+	}
+	if err := l.Check(); err != nil {
+		t.Errorf("Check() error = %v", err)
+	}
+}
+
+func TestNewAuditLogRequiresBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	if _, err := newAuditLog(path, 100, 0); err == nil {
+		t.Errorf("newAuditLog() without backups succeeded, want error")
+	}
+	l, err := newAuditLog(path, 0, 0)
+	if err != nil {
+		t.Fatalf("newAuditLog() without rotation error = %v", err)
+	}
+	_ = l.Close()
+}
+
+func TestAuditLogCheck(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "audit")
+	if err := os.Mkdir(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "audit.jsonl")
+	l, err := newAuditLog(path, 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	// A failed rotation leaves the log file closed
+	_ = l.file.Close()
+	l.file, l.out = nil, nil
+	if err := l.Check(); err != nil {
+		t.Fatalf("Check() error = %v, want the log file to be reopened", err)
+	}
+	if err := l.Write(&AuditRecord{Tool: "get_all_servers"}); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if got := countLines(t, path); got != 1 {
+		t.Errorf("audit log has %d records, want 1", got)
+	}
+
+	_ = l.file.Close()
+	l.file, l.out = nil, nil
+	if err := os.RemoveAll(dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Check(); err == nil {
+		t.Errorf("Check() without a writable log file succeeded, want error")
+	}
+	if err := l.Write(&AuditRecord{Tool: "get_all_servers"}); err == nil {
+		t.Errorf("Write() without a writable log file succeeded, want error")
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Credential represents a client allowed to use the network transports.
+// A client authenticates either with a bearer token or with a client certificate.
+type Credential struct {
+	Name        string      `yaml:"name"`
+	Token       string      `yaml:"token"`
+	CommonName  string      `yaml:"common_name"`
+	Restriction Restriction `yaml:"restriction"`
+}
+
+// Principal represents an authenticated client.
+type Principal struct {
+	Name        string
+	Restriction Restriction
+}
+
+type principalKey struct{}
+
+// principalFromContext returns the client which sent the request, nil if the request is not authenticated.
+func principalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// Authenticator authenticates clients of the network transports by bearer token or by client certificate.
+type Authenticator struct {
+	credentials []Credential
+	certFile    string
+	keyFile     string
+	clientCAs   *x509.CertPool
+}
+
+// newAuthenticator loads the credentials file and the CA used to verify client certificates.
+// Client certificates require the server certificate and key to serve TLS.
+func newAuthenticator(credentialsFile, certFile, keyFile, clientCAFile string) (*Authenticator, error) {
+	a := &Authenticator{certFile: certFile, keyFile: keyFile}
+
+	if (certFile == EmptyString) != (keyFile == EmptyString) {
+		return nil, fmt.Errorf("both the TLS certificate and key are required")
+	}
+
+	if credentialsFile != EmptyString {
+		data, err := os.ReadFile(credentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read credentials file: %w", err)
+		}
+		if err := yaml.Unmarshal(data, &a.credentials); err != nil {
+			return nil, fmt.Errorf("failed to parse credentials file %s: %w", credentialsFile, err)
+		}
+		if err := validateCredentials(a.credentials); err != nil {
+			return nil, fmt.Errorf("invalid credentials file %s: %w", credentialsFile, err)
+		}
+	}
+
+	if clientCAFile != EmptyString {
+		if certFile == EmptyString {
+			return nil, fmt.Errorf("client certificates require a TLS certificate and key")
+		}
+		data, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+		a.clientCAs = x509.NewCertPool()
+		if !a.clientCAs.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in client CA %s", clientCAFile)
+		}
+	}
+
+	return a, nil
+}
+
+func validateCredentials(credentials []Credential) error {
+	tokens := make(map[string]bool, len(credentials))
+	for i, credential := range credentials {
+		if credential.Name == EmptyString {
+			return fmt.Errorf("credential %d has no name", i+1)
+		}
+		if (credential.Token == EmptyString) == (credential.CommonName == EmptyString) {
+			return fmt.Errorf("credential %s needs either a token or a common name", credential.Name)
+		}
+		if credential.Restriction != RestrictionReadOnly && credential.Restriction != RestrictionReadWrite {
+			return fmt.Errorf("credential %s has invalid restriction %q", credential.Name, credential.Restriction)
+		}
+		if credential.Token != EmptyString {
+			if tokens[credential.Token] {
+				return fmt.Errorf("credential %s reuses the token of another credential", credential.Name)
+			}
+			tokens[credential.Token] = true
+		}
+	}
+	return nil
+}
+
+// Enabled reports whether clients have to authenticate.
+func (a *Authenticator) Enabled() bool {
+	return len(a.credentials) > 0 || a.clientCAs != nil
+}
+
+// TLS reports whether the server is served over TLS.
+func (a *Authenticator) TLS() bool {
+	return a.certFile != EmptyString
+}
+
+// TLSConfig returns the TLS configuration verifying client certificates against the client CA.
+// If bearer tokens are configured as well, client certificates are optional.
+func (a *Authenticator) TLSConfig() *tls.Config {
+	config := &tls.Config{MinVersion: tls.VersionTLS12}
+	if a.clientCAs != nil {
+		config.ClientCAs = a.clientCAs
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		if a.hasTokens() {
+			config.ClientAuth = tls.VerifyClientCertIfGiven
+		}
+	}
+	return config
+}
+
+func (a *Authenticator) hasTokens() bool {
+	for _, credential := range a.credentials {
+		if credential.Token != EmptyString {
+			return true
+		}
+	}
+	return false
+}
+
+// authenticate returns the client which sent the request, nil if it could not be authenticated.
+func (a *Authenticator) authenticate(r *http.Request) *Principal {
+	// Client certificates are verified during the TLS handshake
+	if a.clientCAs != nil && r.TLS != nil && len(r.TLS.VerifiedChains) > 0 {
+		commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+		for _, credential := range a.credentials {
+			if credential.CommonName != EmptyString && credential.CommonName == commonName {
+				return &Principal{Name: credential.Name, Restriction: credential.Restriction}
+			}
+		}
+		// Clients with a valid certificate but without a credential may only read
+		return &Principal{Name: commonName, Restriction: RestrictionReadOnly}
+	}
+
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || token == EmptyString {
+		return nil
+	}
+	var principal *Principal
+	for _, credential := range a.credentials {
+		// Compare every token in constant time to not leak which tokens exist
+		if credential.Token != EmptyString && subtle.ConstantTimeCompare([]byte(credential.Token), []byte(token)) == 1 {
+			principal = &Principal{Name: credential.Name, Restriction: credential.Restriction}
+		}
+	}
+	return principal
+}
+
+// Middleware rejects requests of unauthenticated clients and adds the client to the context of the request.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	if !a.Enabled() {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := a.authenticate(r)
+		if principal == nil {
+			log.Printf("Rejected unauthenticated request from %s", r.RemoteAddr)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="mcp-hetzner"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	})
+}
@@ -1,7 +1,17 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
@@ -12,18 +22,107 @@ import (
 // It contains the Certificate ID or Name that is needed to perform the lookup.
 type CertificateReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The certificate id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// CertificateListArgs represents the arguments for listing Certificates.
+// Certificates can be filtered by name on top of the label selector.
+type CertificateListArgs struct {
+	Name string `json:"name,omitempty" jsonschema:"description=Only return the certificate with this name"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// CertificateExpiryArgs represents the arguments for listing Certificates expiring soon.
+type CertificateExpiryArgs struct {
+	HorizonDays int `json:"horizon_days,omitempty" jsonschema:"description=Return certificates expiring within this many days. Defaults to 30"`
+	ProjectArgs
+	OutputArgs
+}
+
+// CertificateUploadArgs represents the arguments required to upload a Certificate.
+type CertificateUploadArgs struct {
+	Name        string            `json:"name" jsonschema:"required,description=The certificate name"`
+	Certificate string            `json:"certificate" jsonschema:"required,description=The PEM encoded certificate chain; leaf certificate first followed by the intermediate certificates"`
+	PrivateKey  string            `json:"private_key" jsonschema:"required,description=The unencrypted PEM encoded private key of the leaf certificate"`
+	Labels      map[string]string `json:"labels,omitempty" jsonschema:"description=User-defined labels for the certificate"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// CertificateManagedCreateArgs represents the arguments required to request a managed Certificate.
+type CertificateManagedCreateArgs struct {
+	Name        string            `json:"name" jsonschema:"required,description=The certificate name"`
+	DomainNames []string          `json:"domain_names" jsonschema:"required,description=Domain names the certificate is issued for (e.g. example.com or *.example.com)"`
+	Labels      map[string]string `json:"labels,omitempty" jsonschema:"description=User-defined labels for the certificate"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// CertificateActionArgs represents the arguments required to change a Certificate.
+// It contains the Certificate ID the change is performed on.
+type CertificateActionArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The certificate id"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// CertificateUpdateArgs represents the arguments required to rename a Certificate or replace its labels.
+type CertificateUpdateArgs struct {
+	ID     int64             `json:"id" jsonschema:"required,description=The certificate id"`
+	Name   string            `json:"name,omitempty" jsonschema:"description=New name of the certificate"`
+	Labels map[string]string `json:"labels,omitempty" jsonschema:"description=New labels of the certificate. Replaces all existing labels"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
 }
 
 type CertificateResponse struct {
-	ID             int64  `json:"id" jsonschema:"required,description=Unique identifier of the certificate"`
-	Name           string `json:"name" jsonschema:"required,description=The name of the certificate"`
-	Labels         map[string]string
-	Type           string    `json:"type" jsonschema:"description=The type of the certificate either of uploaded or managed"`
-	Created        time.Time `json:"created" jsonschema:"description=Timestamp of when the certificate was created"`
-	NotValidBefore time.Time `json:"not_valid_before" jsonschema:"description=Timestamp of when the certificate is not valid before"`
-	NotValidAfter  time.Time `json:"not_valid_after" jsonschema:"description=Timestamp of when the certificate is not valid after"`
-	DomainNames    []string  `json:"domain_names" jsonschema:"description=List of domain names for the certificate"`
-	Fingerprint    string    `json:"fingerprint" jsonschema:"description=The fingerprint of the certificate"`
+	ID             int64                      `json:"id" jsonschema:"required,description=Unique identifier of the certificate"`
+	Name           string                     `json:"name" jsonschema:"required,description=The name of the certificate"`
+	Labels         map[string]string          `json:"labels" jsonschema:"description=User-defined labels for the certificate"`
+	Type           string                     `json:"type" jsonschema:"description=The type of the certificate either of uploaded or managed"`
+	Created        time.Time                  `json:"created" jsonschema:"description=Timestamp of when the certificate was created"`
+	NotValidBefore time.Time                  `json:"not_valid_before" jsonschema:"description=Timestamp of when the certificate is not valid before"`
+	NotValidAfter  time.Time                  `json:"not_valid_after" jsonschema:"description=Timestamp of when the certificate is not valid after"`
+	DomainNames    []string                   `json:"domain_names" jsonschema:"description=List of domain names for the certificate"`
+	Fingerprint    string                     `json:"fingerprint" jsonschema:"description=The fingerprint of the certificate"`
+	Status         *CertificateStatusResponse `json:"status" jsonschema:"description=Issuance and renewal status of a managed certificate; null for uploaded certificates"`
+	UsedBy         []*ResourceRef             `json:"used_by" jsonschema:"description=Load balancers using the certificate"`
+}
+
+type CertificateStatusResponse struct {
+	Issuance     string `json:"issuance" jsonschema:"required,description=Status of the issuance either of pending or completed or failed"`
+	Renewal      string `json:"renewal" jsonschema:"required,description=Status of the renewal either of scheduled or pending or failed or unavailable"`
+	ErrorCode    string `json:"error_code,omitempty" jsonschema:"description=The error code if the issuance or renewal failed"`
+	ErrorMessage string `json:"error_message,omitempty" jsonschema:"description=The error message if the issuance or renewal failed"`
+}
+
+type ExpiringCertificateResponse struct {
+	ID            int64                      `json:"id" jsonschema:"required,description=Unique identifier of the certificate"`
+	Name          string                     `json:"name" jsonschema:"required,description=The name of the certificate"`
+	Type          string                     `json:"type" jsonschema:"required,description=The type of the certificate either of uploaded or managed"`
+	DomainNames   []string                   `json:"domain_names" jsonschema:"description=List of domain names for the certificate"`
+	NotValidAfter *time.Time                 `json:"not_valid_after" jsonschema:"description=Timestamp of when the certificate expires; null if it was never issued"`
+	DaysRemaining *int                       `json:"days_remaining" jsonschema:"description=Full days until the certificate expires; negative if it is expired and null if it was never issued"`
+	Failed        bool                       `json:"failed" jsonschema:"required,description=Whether the issuance or renewal of a managed certificate failed"`
+	Status        *CertificateStatusResponse `json:"status" jsonschema:"description=Issuance and renewal status of a managed certificate; null for uploaded certificates"`
+	UsedBy        []*ResourceRef             `json:"used_by" jsonschema:"description=Load balancers using the certificate"`
+}
+
+type CertificateCreateResponse struct {
+	Certificate *CertificateResponse `json:"certificate" jsonschema:"required,description=The created certificate"`
+	Action      *ActionResponse      `json:"action" jsonschema:"required,description=The action issuing the certificate"`
+}
+
+type CertificateActionResponse struct {
+	Certificate *CertificateResponse `json:"certificate" jsonschema:"description=The certificate the action was performed on; null if it was deleted"`
+	Action      *ActionResponse      `json:"action" jsonschema:"description=The triggered action; null if the change did not need one"`
 }
 
 func toCertificateResponse(c *hcloud.Certificate) *CertificateResponse {
@@ -40,25 +139,365 @@ func toCertificateResponse(c *hcloud.Certificate) *CertificateResponse {
 		NotValidAfter:  c.NotValidAfter,
 		DomainNames:    c.DomainNames,
 		Fingerprint:    c.Fingerprint,
+		Status:         toCertificateStatusResponse(c.Status),
+		UsedBy:         toCertificateUsedByRefs(c.UsedBy),
+	}
+}
+
+func toCertificateStatusResponse(s *hcloud.CertificateStatus) *CertificateStatusResponse {
+	if s == nil {
+		return nil
+	}
+	result := &CertificateStatusResponse{
+		Issuance: string(s.Issuance),
+		Renewal:  string(s.Renewal),
+	}
+	if s.Error != nil {
+		result.ErrorCode = string(s.Error.Code)
+		result.ErrorMessage = s.Error.Message
+	}
+	return result
+}
+
+func toCertificateUsedByRefs(refs []hcloud.CertificateUsedByRef) []*ResourceRef {
+	result := make([]*ResourceRef, 0, len(refs))
+	for _, ref := range refs {
+		if ref.Type == hcloud.CertificateUsedByRefTypeLoadBalancer {
+			result = append(result, toResourceRef(ref.ID, EmptyString))
+		}
 	}
+	return result
+}
+
+// expiringCertificates returns the Certificates expiring before the horizon and the managed Certificates whose
+// issuance or renewal failed, sorted by expiry. Certificates which were never issued come first.
+func expiringCertificates(ctx context.Context, horizon time.Duration, now time.Time) ([]*ExpiringCertificateResponse, error) {
+	certificates, err := hcloudClient(ctx).Certificate.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiring []*hcloud.Certificate
+	usedBy := false
+	for _, certificate := range certificates {
+		if !visibleInScope(certificate.Labels) {
+			continue
+		}
+		failed := certificate.Status != nil && certificate.Status.IsFailed()
+		if !failed && (certificate.NotValidAfter.IsZero() || certificate.NotValidAfter.Sub(now) > horizon) {
+			continue
+		}
+		expiring = append(expiring, certificate)
+		usedBy = usedBy || len(certificate.UsedBy) > 0
+	}
+	slices.SortStableFunc(expiring, func(a, b *hcloud.Certificate) int {
+		return a.NotValidAfter.Compare(b.NotValidAfter)
+	})
+
+	// Load balancers are only referenced by ID, the names of load balancers outside of the scope are not revealed
+	loadBalancerNames := make(map[int64]string)
+	if usedBy {
+		loadBalancers, err := hcloudClient(ctx).LoadBalancer.All(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, loadBalancer := range loadBalancers {
+			if !visibleInScope(loadBalancer.Labels) {
+				continue
+			}
+			loadBalancerNames[loadBalancer.ID] = loadBalancer.Name
+		}
+	}
+
+	result := make([]*ExpiringCertificateResponse, 0, len(expiring))
+	for _, certificate := range expiring {
+		item := &ExpiringCertificateResponse{
+			ID:          certificate.ID,
+			Name:        certificate.Name,
+			Type:        string(certificate.Type),
+			DomainNames: certificate.DomainNames,
+			Failed:      certificate.Status != nil && certificate.Status.IsFailed(),
+			Status:      toCertificateStatusResponse(certificate.Status),
+			UsedBy:      toCertificateUsedByRefs(certificate.UsedBy),
+		}
+		if !certificate.NotValidAfter.IsZero() {
+			days := int(math.Floor(certificate.NotValidAfter.Sub(now).Hours() / 24))
+			item.NotValidAfter = &certificate.NotValidAfter
+			item.DaysRemaining = &days
+		}
+		for _, ref := range item.UsedBy {
+			ref.Name = loadBalancerNames[ref.ID]
+		}
+		result = append(result, item)
+	}
+	return result, nil
+}
+
+// certificateExpiryWarning is how long before their expiry certificates are reported as expiring soon,
+// both when uploading them and by get_expiring_certificates.
+const certificateExpiryWarning = 30 * 24 * time.Hour
+
+// domainNamePattern matches domain names managed certificates can be issued for, including wildcards.
+var domainNamePattern = regexp.MustCompile(`^(\*\.)?([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]{2,63}$`)
+
+// parseCertificateChain parses a PEM encoded certificate chain, leaf certificate first.
+// Blocks other than certificates are reported by their type only, so pasted private keys are never echoed.
+func parseCertificateChain(field, chainPEM string, errs *ValidationError) []*x509.Certificate {
+	var chain []*x509.Certificate
+	rest := []byte(chainPEM)
+	for i := 0; ; i++ {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			errs.add(field, "block %d is a %s, only CERTIFICATE blocks are allowed", i, block.Type)
+			continue
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			errs.add(field, "block %d is no valid certificate: %v", i, err)
+			continue
+		}
+		chain = append(chain, certificate)
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		errs.add(field, "contains data which is not PEM encoded")
+	}
+	if len(chain) == 0 && len(errs.Errors) == 0 {
+		errs.add(field, "no PEM encoded certificate found")
+	}
+	return chain
+}
+
+// parseCertificateKey parses an unencrypted PEM encoded private key in PKCS #1, PKCS #8 or SEC 1 format.
+// Errors never contain the key.
+func parseCertificateKey(field, keyPEM string, errs *ValidationError) crypto.PublicKey {
+	block, rest := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		errs.add(field, "no PEM encoded private key found")
+		return nil
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		errs.add(field, "must contain exactly one private key")
+		return nil
+	}
+
+	var key any
+	var err error
+	switch {
+	case block.Type == "ENCRYPTED PRIVATE KEY" || strings.Contains(block.Headers["Proc-Type"], "ENCRYPTED"):
+		errs.add(field, "private key is encrypted, decrypt it first (e.g. openssl pkey -in key.pem)")
+		return nil
+	case block.Type == "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case block.Type == "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case block.Type == "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		errs.add(field, "%s is no private key", block.Type)
+		return nil
+	}
+	if err != nil {
+		errs.add(field, "invalid %s", strings.ToLower(block.Type))
+		return nil
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		errs.add(field, "unsupported private key type %T", key)
+		return nil
+	}
+	return signer.Public()
+}
+
+// checkCertificateUpload checks that the private key matches the leaf certificate, that every certificate
+// of the chain is signed by the next one and that no certificate is expired or not yet valid.
+// Certificates expiring soon and incomplete chains are returned as warnings.
+func checkCertificateUpload(chainPEM, keyPEM string, now time.Time, errs *ValidationError) (*x509.Certificate, []string) {
+	chain := parseCertificateChain("certificate", chainPEM, errs)
+	publicKey := parseCertificateKey("private_key", keyPEM, errs)
+	if len(chain) == 0 {
+		return nil, nil
+	}
+
+	var warnings []string
+	leaf := chain[0]
+	if publicKey != nil {
+		if key, ok := publicKey.(interface{ Equal(crypto.PublicKey) bool }); !ok || !key.Equal(leaf.PublicKey) {
+			errs.add("private_key", "does not match the public key of the leaf certificate %s", leaf.Subject)
+		}
+	}
+	for i, certificate := range chain {
+		switch {
+		case now.After(certificate.NotAfter):
+			errs.add("certificate", "certificate %d (%s) expired on %s", i, certificate.Subject, certificate.NotAfter.Format(time.DateOnly))
+		case now.Before(certificate.NotBefore):
+			errs.add("certificate", "certificate %d (%s) is not valid before %s", i, certificate.Subject, certificate.NotBefore.Format(time.DateOnly))
+		case certificate.NotAfter.Sub(now) < certificateExpiryWarning:
+			warnings = append(warnings, fmt.Sprintf("certificate %d (%s) expires on %s", i, certificate.Subject, certificate.NotAfter.Format(time.DateOnly)))
+		}
+		if i+1 < len(chain) {
+			if err := certificate.CheckSignatureFrom(chain[i+1]); err != nil {
+				errs.add("certificate", "certificate %d (%s) is not signed by certificate %d (%s), the chain must start with the leaf certificate followed by its issuers", i, certificate.Subject, i+1, chain[i+1].Subject)
+			}
+		}
+	}
+	if last := chain[len(chain)-1]; len(chain) == 1 && !bytes.Equal(last.RawIssuer, last.RawSubject) {
+		warnings = append(warnings, fmt.Sprintf("the chain only contains the leaf certificate, clients may fail to verify it without the intermediate certificates of %s", last.Issuer))
+	}
+	return leaf, warnings
+}
+
+// toCertificateUploadOpts validates the arguments and returns the options to upload the Certificate,
+// the Certificate expected to be created and warnings about the certificate chain.
+func toCertificateUploadOpts(ctx context.Context, args CertificateUploadArgs) (hcloud.CertificateCreateOpts, *hcloud.Certificate, []string, error) {
+	var errs ValidationError
+	if args.Name == EmptyString {
+		errs.add("name", "missing certificate name")
+	}
+	leaf, warnings := checkCertificateUpload(args.Certificate, args.PrivateKey, time.Now(), &errs)
+	labels, err := scopeLabels(args.Labels)
+	if err != nil {
+		errs.add("labels", "%v", err)
+	}
+	if err := errs.err(); err != nil {
+		return hcloud.CertificateCreateOpts{}, nil, nil, err
+	}
+	if err := checkCertificateName(ctx, args.Name); err != nil {
+		return hcloud.CertificateCreateOpts{}, nil, nil, err
+	}
+
+	fingerprint := sha256.Sum256(leaf.Raw)
+	hexFingerprint := make([]string, 0, len(fingerprint))
+	for _, b := range fingerprint {
+		hexFingerprint = append(hexFingerprint, fmt.Sprintf("%02x", b))
+	}
+	domainNames := leaf.DNSNames
+	if len(domainNames) == 0 && leaf.Subject.CommonName != EmptyString {
+		domainNames = []string{leaf.Subject.CommonName}
+	}
+
+	opts := hcloud.CertificateCreateOpts{
+		Name:        args.Name,
+		Type:        hcloud.CertificateTypeUploaded,
+		Certificate: args.Certificate,
+		PrivateKey:  args.PrivateKey,
+		Labels:      labels,
+	}
+	expected := &hcloud.Certificate{
+		Name:           args.Name,
+		Labels:         labels,
+		Type:           hcloud.CertificateTypeUploaded,
+		Created:        time.Now(),
+		NotValidBefore: leaf.NotBefore,
+		NotValidAfter:  leaf.NotAfter,
+		DomainNames:    domainNames,
+		Fingerprint:    strings.Join(hexFingerprint, ":"),
+	}
+	return opts, expected, warnings, nil
+}
+
+// toCertificateManagedCreateOpts validates the arguments and returns the options to request the managed Certificate.
+func toCertificateManagedCreateOpts(ctx context.Context, args CertificateManagedCreateArgs) (hcloud.CertificateCreateOpts, error) {
+	var errs ValidationError
+	if args.Name == EmptyString {
+		errs.add("name", "missing certificate name")
+	}
+	if len(args.DomainNames) == 0 {
+		errs.add("domain_names", "at least one domain name is required")
+	}
+	seen := make(map[string]bool)
+	for i, domainName := range args.DomainNames {
+		field := fmt.Sprintf("domain_names[%d]", i)
+		if !domainNamePattern.MatchString(domainName) {
+			errs.add(field, "invalid domain name %q", domainName)
+		}
+		if seen[strings.ToLower(domainName)] {
+			errs.add(field, "duplicate domain name %q", domainName)
+		}
+		seen[strings.ToLower(domainName)] = true
+	}
+	labels, err := scopeLabels(args.Labels)
+	if err != nil {
+		errs.add("labels", "%v", err)
+	}
+	if err := errs.err(); err != nil {
+		return hcloud.CertificateCreateOpts{}, err
+	}
+	if err := checkCertificateName(ctx, args.Name); err != nil {
+		return hcloud.CertificateCreateOpts{}, err
+	}
+
+	return hcloud.CertificateCreateOpts{
+		Name:        args.Name,
+		Type:        hcloud.CertificateTypeManaged,
+		DomainNames: args.DomainNames,
+		Labels:      labels,
+	}, nil
+}
+
+// checkCertificateName fails if a Certificate with the name already exists.
+func checkCertificateName(ctx context.Context, name string) error {
+	existing, _, err := hcloudClient(ctx).Certificate.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return fmt.Errorf("certificate %s already exists", name)
+	}
+	return nil
+}
+
+// getCertificate retrieves a Certificate by its ID and fails if it does not exist.
+func getCertificate(ctx context.Context, id int64) (*hcloud.Certificate, error) {
+	certificate, _, err := hcloudClient(ctx).Certificate.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if certificate == nil {
+		return nil, fmt.Errorf("certificate %d not found", id)
+	}
+	return certificate, nil
+}
+
+// certificateTarget runs actions on Certificates with runAction.
+var certificateTarget = actionTarget[hcloud.Certificate, CertificateResponse]{
+	name:       "certificate",
+	actionType: "certificate",
+	get:        getCertificate,
+	labels:     func(certificate *hcloud.Certificate) map[string]string { return certificate.Labels },
+	convert:    toCertificateResponse,
+	respond: func(certificate *CertificateResponse, actions []*ActionResponse) any {
+		return &CertificateActionResponse{Certificate: certificate, Action: firstAction(actions)}
+	},
 }
 
 // CertificateTools
 var certificateTools = []Tool{
 	{
 		Name:        "get_all_certificates",
-		Description: "Returns all Certificates objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*CertificateResponse, error) {
-				result, err := client.Certificate.All(context.Background())
+		Description: "Returns a page of Certificates, optionally filtered by name and label selector.",
+		Handler: func(ctx context.Context, args CertificateListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*CertificateResponse], error) {
+				listOpts, err := args.listOpts()
+				if err != nil {
+					return nil, err
+				}
+				opts := hcloud.CertificateListOpts{
+					ListOpts: listOpts,
+					Name:     args.Name,
+				}
+				result, resp, err := hcloudClient(ctx).Certificate.List(ctx, opts)
 				if err != nil {
 					return nil, err
 				}
-				var filtered []*CertificateResponse
-				for _, c := range result {
-					filtered = append(filtered, toCertificateResponse(c))
+				items := make([]*CertificateResponse, 0, len(result))
+				for _, item := range result {
+					items = append(items, toCertificateResponse(item))
 				}
-				return filtered, nil
+				return newListResponse(items, resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -66,15 +505,193 @@ var certificateTools = []Tool{
 	{
 		Name:        "get_a_certificate_by_id_or_name",
 		Description: "Retrieves a Certificate by its ID or Name. Get retrieves a Certificate by its ID if the input can be parsed as an integer, otherwise it retrieves a Certificate by its name. If the Certificate does not exist, nil is returned.",
-		Handler: func(args CertificateReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*CertificateResponse, error) {
-				result, _, err := client.Certificate.Get(context.Background(), args.IDOrName)
+		Handler: func(ctx context.Context, args CertificateReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*CertificateResponse, error) {
+				result, _, err := hcloudClient(ctx).Certificate.Get(ctx, args.IDOrName)
 				if err != nil {
 					return nil, err
 				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
 				return toCertificateResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
 	},
+	{
+		Name:        "get_expiring_certificates",
+		Description: "Returns the Certificates expiring within the given number of days (30 by default) and the managed Certificates whose issuance or renewal failed, sorted by expiry. Each certificate has its days remaining, its type and the Load Balancers using it. Uploaded certificates have to be replaced before they expire; managed certificates are renewed automatically unless their renewal fails.",
+		Handler: func(ctx context.Context, args CertificateExpiryArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() ([]*ExpiringCertificateResponse, error) {
+				if args.HorizonDays < 0 {
+					return nil, fmt.Errorf("horizon_days must not be negative")
+				}
+				horizon := certificateExpiryWarning
+				if args.HorizonDays > 0 {
+					horizon = time.Duration(args.HorizonDays) * 24 * time.Hour
+				}
+				return expiringCertificates(ctx, horizon, time.Now())
+			})
+		},
+		Restriction: RestrictionReadOnly,
+	},
+	{
+		Name:        "upload_a_certificate",
+		Description: "Uploads a PEM certificate chain with its private key for https services of Load Balancers. The chain and key are checked locally first: the key must match the leaf certificate, every certificate must be signed by the next one and none may be expired. Uploaded certificates are not renewed automatically.",
+		Handler: func(ctx context.Context, args CertificateUploadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				opts, expected, warnings, err := toCertificateUploadOpts(ctx, args)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					return newPlannedChange("create_certificate", toCertificateResponse(expected), warnings...), nil
+				}
+				result, _, err := hcloudClient(ctx).Certificate.Create(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return toCertificateResponse(result), nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "create_a_managed_certificate",
+		Description: "Requests a managed Let's Encrypt certificate for the given domain names. Hetzner issues and renews it automatically, which requires the DNS zones of the domains to be hosted by Hetzner DNS. Issuance may take several minutes; wait for the action or check the status of the certificate.",
+		Handler: func(ctx context.Context, args CertificateManagedCreateArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				opts, err := toCertificateManagedCreateOpts(ctx, args)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					expected := &hcloud.Certificate{
+						Name:        opts.Name,
+						Labels:      opts.Labels,
+						Type:        opts.Type,
+						Created:     time.Now(),
+						DomainNames: opts.DomainNames,
+						Status:      &hcloud.CertificateStatus{Issuance: hcloud.CertificateStatusTypePending, Renewal: hcloud.CertificateStatusTypeUnavailable},
+					}
+					return newPlannedChange("create_certificate", &CertificateCreateResponse{
+						Certificate: toCertificateResponse(expected),
+						Action:      plannedAction("create_certificate", ActionResource{ID: 0, Type: "certificate"}),
+					}, "the DNS zones of all domain names must be hosted by Hetzner DNS, otherwise the issuance fails"), nil
+				}
+				result, _, err := hcloudClient(ctx).Certificate.CreateCertificate(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				recordActions(ctx, result.Action)
+				if args.Wait && result.Action != nil {
+					result.Action, err = waitForAction(ctx, result.Action)
+					if err != nil {
+						return nil, err
+					}
+					result.Certificate, _, err = hcloudClient(ctx).Certificate.GetByID(ctx, result.Certificate.ID)
+					if err != nil {
+						return nil, err
+					}
+				}
+				return &CertificateCreateResponse{
+					Certificate: toCertificateResponse(result.Certificate),
+					Action:      toActionResponse(result.Action),
+				}, nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "retry_managed_certificate_issuance",
+		Description: "Retries the issuance or renewal of a managed certificate which failed, e.g. after fixing the DNS records of its domains.",
+		Handler: func(ctx context.Context, args CertificateActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, certificateTarget, args.ID, args.WriteArgs, "issue_certificate", false,
+				func(ctx context.Context, certificate *hcloud.Certificate) (*CertificateResponse, []string, error) {
+					if certificate.Type != hcloud.CertificateTypeManaged {
+						return nil, nil, fmt.Errorf("certificate %d is an uploaded certificate, only managed certificates can be issued again", certificate.ID)
+					}
+					if certificate.Status == nil || !certificate.Status.IsFailed() {
+						return nil, nil, fmt.Errorf("neither the issuance nor the renewal of certificate %d failed", certificate.ID)
+					}
+					var warnings []string
+					if certificate.Status.Error != nil {
+						warnings = append(warnings, fmt.Sprintf("the previous attempt failed with: %s", certificate.Status.Error.Message))
+					}
+					// Only the failed step is retried
+					expected := toCertificateResponse(certificate)
+					if certificate.Status.Issuance == hcloud.CertificateStatusTypeFailed {
+						expected.Status.Issuance = string(hcloud.CertificateStatusTypePending)
+					}
+					if certificate.Status.Renewal == hcloud.CertificateStatusTypeFailed {
+						expected.Status.Renewal = string(hcloud.CertificateStatusTypePending)
+					}
+					expected.Status.ErrorCode, expected.Status.ErrorMessage = EmptyString, EmptyString
+					return expected, warnings, nil
+				},
+				singleAction(func(ctx context.Context, certificate *hcloud.Certificate) (*hcloud.Action, error) {
+					action, _, err := hcloudClient(ctx).Certificate.RetryIssuance(ctx, certificate)
+					return action, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "update_a_certificate",
+		Description: "Renames a Certificate or replaces its labels.",
+		Handler: func(ctx context.Context, args CertificateUpdateArgs) (*mcpgolang.ToolResponse, error) {
+			var opts hcloud.CertificateUpdateOpts
+			return runAction(ctx, certificateTarget, args.ID, args.WriteArgs, "update_certificate", true,
+				func(ctx context.Context, certificate *hcloud.Certificate) (*CertificateResponse, []string, error) {
+					if args.Name == EmptyString && args.Labels == nil {
+						return nil, nil, fmt.Errorf("either name or labels is required")
+					}
+					expected := toCertificateResponse(certificate)
+					if args.Name != EmptyString && args.Name != certificate.Name {
+						if err := checkCertificateName(ctx, args.Name); err != nil {
+							return nil, nil, err
+						}
+						opts.Name = args.Name
+						expected.Name = args.Name
+					}
+					if args.Labels != nil {
+						labels, err := scopeLabels(args.Labels)
+						if err != nil {
+							return nil, nil, err
+						}
+						opts.Labels = labels
+						expected.Labels = labels
+					}
+					return expected, nil, nil
+				},
+				singleAction(func(ctx context.Context, certificate *hcloud.Certificate) (*hcloud.Action, error) {
+					_, _, err := hcloudClient(ctx).Certificate.Update(ctx, certificate, opts)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_a_certificate",
+		Description: "Deletes a Certificate. Certificates still used by https services of Load Balancers are not deleted; remove them from the services first.",
+		Handler: func(ctx context.Context, args CertificateActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, certificateTarget, args.ID, args.WriteArgs, "delete_certificate", true,
+				func(ctx context.Context, certificate *hcloud.Certificate) (*CertificateResponse, []string, error) {
+					if len(certificate.UsedBy) > 0 {
+						ids := make([]string, 0, len(certificate.UsedBy))
+						for _, ref := range certificate.UsedBy {
+							ids = append(ids, fmt.Sprint(ref.ID))
+						}
+						return nil, nil, fmt.Errorf("certificate %d is used by load balancer %s, remove it from their services with update_load_balancer_service first", certificate.ID, strings.Join(ids, ", "))
+					}
+					return nil, nil, nil
+				},
+				singleAction(func(ctx context.Context, certificate *hcloud.Certificate) (*hcloud.Action, error) {
+					_, err := hcloudClient(ctx).Certificate.Delete(ctx, certificate)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
 }
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificateNow is the time the test certificates are checked at.
+var testCertificateNow = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+type testCertificate struct {
+	certificate *x509.Certificate
+	key         crypto.Signer
+}
+
+// newTestCertificate creates a certificate for the common name valid between the offsets to testCertificateNow.
+// It is self-signed without a parent, CA certificates have no DNS names.
+func newTestCertificate(t *testing.T, commonName string, notBefore, notAfter time.Duration, parent *testCertificate) *testCertificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    testCertificateNow.Add(notBefore),
+		NotAfter:     testCertificateNow.Add(notAfter),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	if strings.Contains(commonName, "CA") {
+		template.IsCA, template.BasicConstraintsValid = true, true
+		template.KeyUsage |= x509.KeyUsageCertSign
+	} else {
+		template.DNSNames = []string{commonName}
+	}
+	issuer, issuerKey := template, crypto.Signer(key)
+	if parent != nil {
+		issuer, issuerKey = parent.certificate, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, issuer, key.Public(), issuerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testCertificate{certificate: certificate, key: key}
+}
+
+// certificatePEM returns the PEM encoded chain of the certificates.
+func certificatePEM(certificates ...*testCertificate) string {
+	var b strings.Builder
+	for _, c := range certificates {
+		b.Write(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.certificate.Raw}))
+	}
+	return b.String()
+}
+
+// privateKeyPEM returns the private key of the certificate PEM encoded in PKCS #8 format.
+func privateKeyPEM(t *testing.T, key crypto.Signer) string {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestCheckCertificateUpload(t *testing.T) {
+	day := 24 * time.Hour
+	root := newTestCertificate(t, "Test Root CA", -365*day, 3650*day, nil)
+	intermediate := newTestCertificate(t, "Test Intermediate CA", -30*day, 365*day, root)
+	leaf := newTestCertificate(t, "www.example.com", -day, 90*day, intermediate)
+	other := newTestCertificate(t, "other.example.com", -day, 90*day, intermediate)
+	expiring := newTestCertificate(t, "expiring.example.com", -80*day, 10*day, intermediate)
+	expired := newTestCertificate(t, "expired.example.com", -100*day, -10*day, intermediate)
+	notYetValid := newTestCertificate(t, "future.example.com", day, 90*day, intermediate)
+	selfSigned := newTestCertificate(t, "self.example.com", -day, 90*day, nil)
+
+	tests := []struct {
+		name         string
+		certificate  string
+		privateKey   string
+		wantErrors   []FieldError
+		wantWarnings []string
+	}{
+		{
+			name:        "leaf and intermediate",
+			certificate: certificatePEM(leaf, intermediate),
+			privateKey:  privateKeyPEM(t, leaf.key),
+		},
+		{
+			name:        "full chain",
+			certificate: certificatePEM(leaf, intermediate, root),
+			privateKey:  privateKeyPEM(t, leaf.key),
+		},
+		{
+			name:        "self-signed",
+			certificate: certificatePEM(selfSigned),
+			privateKey:  privateKeyPEM(t, selfSigned.key),
+		},
+		{
+			name:        "leaf only",
+			certificate: certificatePEM(leaf),
+			privateKey:  privateKeyPEM(t, leaf.key),
+			wantWarnings: []string{
+				"the chain only contains the leaf certificate, clients may fail to verify it without the intermediate certificates of CN=Test Intermediate CA",
+			},
+		},
+		{
+			name:        "expiring soon",
+			certificate: certificatePEM(expiring, intermediate),
+			privateKey:  privateKeyPEM(t, expiring.key),
+			wantWarnings: []string{
+				"certificate 0 (CN=expiring.example.com) expires on 2026-10-28",
+			},
+		},
+		{
+			name:        "key mismatch",
+			certificate: certificatePEM(leaf, intermediate),
+			privateKey:  privateKeyPEM(t, other.key),
+			wantErrors: []FieldError{
+				{Field: "private_key", Message: "does not match the public key of the leaf certificate CN=www.example.com"},
+			},
+		},
+		{
+			name:        "issuer before leaf",
+			certificate: certificatePEM(intermediate, leaf),
+			privateKey:  privateKeyPEM(t, leaf.key),
+			wantErrors: []FieldError{
+				{Field: "private_key", Message: "does not match the public key of the leaf certificate CN=Test Intermediate CA"},
+				{Field: "certificate", Message: "certificate 0 (CN=Test Intermediate CA) is not signed by certificate 1 (CN=www.example.com), the chain must start with the leaf certificate followed by its issuers"},
+			},
+		},
+		{
+			name:        "unrelated certificates",
+			certificate: certificatePEM(leaf, selfSigned),
+			privateKey:  privateKeyPEM(t, leaf.key),
+			wantErrors: []FieldError{
+				{Field: "certificate", Message: "certificate 0 (CN=www.example.com) is not signed by certificate 1 (CN=self.example.com), the chain must start with the leaf certificate followed by its issuers"},
+			},
+		},
+		{
+			name:        "expired",
+			certificate: certificatePEM(expired, intermediate),
+			privateKey:  privateKeyPEM(t, expired.key),
+			wantErrors: []FieldError{
+				{Field: "certificate", Message: "certificate 0 (CN=expired.example.com) expired on 2026-10-08"},
+			},
+		},
+		{
+			name:        "not yet valid",
+			certificate: certificatePEM(notYetValid, intermediate),
+			privateKey:  privateKeyPEM(t, notYetValid.key),
+			wantErrors: []FieldError{
+				{Field: "certificate", Message: "certificate 0 (CN=future.example.com) is not valid before 2026-10-19"},
+			},
+		},
+		{
+			name:        "private key in the chain",
+			certificate: certificatePEM(leaf) + privateKeyPEM(t, leaf.key) + certificatePEM(intermediate),
+			privateKey:  privateKeyPEM(t, leaf.key),
+			wantErrors: []FieldError{
+				{Field: "certificate", Message: "block 1 is a PRIVATE KEY, only CERTIFICATE blocks are allowed"},
+			},
+		},
+		{
+			name:        "no certificate",
+			certificate: "www.example.com",
+			privateKey:  privateKeyPEM(t, leaf.key),
+			wantErrors: []FieldError{
+				{Field: "certificate", Message: "contains data which is not PEM encoded"},
+			},
+		},
+		{
+			name:        "encrypted key",
+			certificate: certificatePEM(leaf, intermediate),
+			privateKey:  string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("encrypted")})),
+			wantErrors: []FieldError{
+				{Field: "private_key", Message: "private key is encrypted, decrypt it first (e.g. openssl pkey -in key.pem)"},
+			},
+		},
+		{
+			name:        "missing key",
+			certificate: certificatePEM(leaf, intermediate),
+			wantErrors: []FieldError{
+				{Field: "private_key", Message: "no PEM encoded private key found"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs ValidationError
+			_, warnings := checkCertificateUpload(tt.certificate, tt.privateKey, testCertificateNow, &errs)
+			if !slices.Equal(errs.Errors, tt.wantErrors) {
+				t.Errorf("checkCertificateUpload() errors = %v, want %v", errs.Errors, tt.wantErrors)
+			}
+			if !slices.Equal(warnings, tt.wantWarnings) {
+				t.Errorf("checkCertificateUpload() warnings = %q, want %q", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+func TestParseCertificateKey(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecDER, err := x509.MarshalECPrivateKey(ecdsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secret := privateKeyPEM(t, ecdsaKey)
+
+	tests := []struct {
+		name      string
+		keyPEM    string
+		wantKey   crypto.PublicKey
+		wantError string
+	}{
+		{
+			name:    "pkcs8 ecdsa",
+			keyPEM:  secret,
+			wantKey: ecdsaKey.Public(),
+		},
+		{
+			name:    "pkcs8 ed25519",
+			keyPEM:  privateKeyPEM(t, ed25519Key),
+			wantKey: ed25519Key.Public(),
+		},
+		{
+			name:    "pkcs1 rsa",
+			keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})),
+			wantKey: rsaKey.Public(),
+		},
+		{
+			name:    "sec1 ec",
+			keyPEM:  string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecDER})),
+			wantKey: ecdsaKey.Public(),
+		},
+		{
+			name:      "encrypted pkcs8",
+			keyPEM:    string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: []byte("encrypted")})),
+			wantError: "private key is encrypted, decrypt it first (e.g. openssl pkey -in key.pem)",
+		},
+		{
+			name: "encrypted pkcs1",
+			keyPEM: string(pem.EncodeToMemory(&pem.Block{
+				Type:    "RSA PRIVATE KEY",
+				Headers: map[string]string{"Proc-Type": "4,ENCRYPTED", "DEK-Info": "AES-128-CBC,00112233445566778899AABBCCDDEEFF"},
+				Bytes:   []byte("encrypted"),
+			})),
+			wantError: "private key is encrypted, decrypt it first (e.g. openssl pkey -in key.pem)",
+		},
+		{
+			name:      "certificate instead of key",
+			keyPEM:    string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("certificate")})),
+			wantError: "CERTIFICATE is no private key",
+		},
+		{
+			name:      "two keys",
+			keyPEM:    secret + secret,
+			wantError: "must contain exactly one private key",
+		},
+		{
+			name:      "mislabeled key",
+			keyPEM:    string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: ecDER})),
+			wantError: "invalid rsa private key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs ValidationError
+			got := parseCertificateKey("private_key", tt.keyPEM, &errs)
+			if tt.wantError != EmptyString {
+				want := []FieldError{{Field: "private_key", Message: tt.wantError}}
+				if !slices.Equal(errs.Errors, want) {
+					t.Errorf("parseCertificateKey() errors = %v, want %v", errs.Errors, want)
+				}
+				return
+			}
+			if len(errs.Errors) > 0 {
+				t.Fatalf("parseCertificateKey() errors = %v", errs.Errors)
+			}
+			if key, ok := got.(interface{ Equal(crypto.PublicKey) bool }); !ok || !key.Equal(tt.wantKey) {
+				t.Errorf("parseCertificateKey() = %v, want %v", got, tt.wantKey)
+			}
+		})
+	}
+}
@@ -1,10 +1,24 @@
 package main
 
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	mcpgolang "github.com/metoro-io/mcp-golang"
+)
+
 // EmptyString is a constant that represents an empty string.
 const EmptyString = ""
 
-// NoArgs represents an empty structure used when no arguments are required.
-type NoArgs struct{}
+// NoArgs represents the arguments of tools which require no arguments apart from the project.
+type NoArgs struct {
+	ProjectArgs
+	OutputArgs
+}
 
 // Restriction represents the restriction of a tool.
 type Restriction string
@@ -20,9 +34,195 @@ const RestrictionReadWrite Restriction = "read_write"
 // ListArgs represents the arguments for listing resources.
 // It includes pagination information like page number, items per page, and a label selector for filtering.
 type ListArgs struct {
-	Page          int    `json:"page" jsonschema:"description=Page (starting at 1)"`
-	PerPage       int    `json:"per_page" jsonschema:"description=Items per page (0 means default)"`
-	LabelSelector string `json:"label_selector" jsonschema:"description=Label selector for filtering by labels"`
+	Page          int    `json:"page,omitempty" jsonschema:"description=Page (starting at 1)"`
+	PerPage       int    `json:"per_page,omitempty" jsonschema:"description=Items per page (0 means default of 25; at most 50)"`
+	LabelSelector string `json:"label_selector,omitempty" jsonschema:"description=Label selector for filtering by labels (e.g. env=production)"`
+}
+
+// maxPerPage is the maximum number of items per page supported by the Hetzner Cloud API.
+const maxPerPage = 50
+
+// listOpts returns the list options for the requested page. If lists are filtered by
+// the label scope, the label selector is narrowed down to resources inside the scope.
+func (a ListArgs) listOpts() (hcloud.ListOpts, error) {
+	if a.Page < 0 {
+		return hcloud.ListOpts{}, fmt.Errorf("page must not be negative")
+	}
+	if a.PerPage < 0 || a.PerPage > maxPerPage {
+		return hcloud.ListOpts{}, fmt.Errorf("per_page must be between 0 and %d", maxPerPage)
+	}
+
+	opts := hcloud.ListOpts{Page: a.Page, PerPage: a.PerPage, LabelSelector: a.LabelSelector}
+	if scope != nil && scope.FilterLists {
+		opts.LabelSelector = scopeLabelSelector(a.LabelSelector)
+	}
+	return opts, nil
+}
+
+// ListResponse represents a page of resources together with its pagination metadata.
+type ListResponse[T any] struct {
+	Items        []T  `json:"items" jsonschema:"required,description=The resources on the page"`
+	Page         int  `json:"page" jsonschema:"required,description=The current page"`
+	PerPage      int  `json:"per_page" jsonschema:"required,description=Items per page"`
+	NextPage     *int `json:"next_page" jsonschema:"description=The next page, null on the last page"`
+	TotalEntries int  `json:"total_entries" jsonschema:"required,description=The total number of resources on all pages"`
+}
+
+func newListResponse[T any](items []T, resp *hcloud.Response) *ListResponse[T] {
+	result := &ListResponse[T]{Items: items}
+	if result.Items == nil {
+		result.Items = []T{}
+	}
+	if resp != nil && resp.Meta.Pagination != nil {
+		pagination := resp.Meta.Pagination
+		result.Page, result.PerPage, result.TotalEntries = pagination.Page, pagination.PerPage, pagination.TotalEntries
+		if pagination.NextPage != 0 {
+			result.NextPage = &pagination.NextPage
+		}
+	}
+	return result
+}
+
+// FieldError describes why the argument at a path such as rules[0].port is invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError lists all invalid arguments of a request, so that they can be fixed at once
+// instead of one error per call.
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (e *ValidationError) add(field, format string, a ...any) {
+	e.Errors = append(e.Errors, FieldError{Field: field, Message: fmt.Sprintf(format, a...)})
+}
+
+// err returns the validation error, or nil if all arguments are valid.
+func (e *ValidationError) err() error {
+	if len(e.Errors) == 0 {
+		return nil
+	}
+	return e
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	if len(e.Errors) == 1 {
+		b.WriteString("1 invalid argument, nothing was changed:")
+	} else {
+		fmt.Fprintf(&b, "%d invalid arguments, nothing was changed:", len(e.Errors))
+	}
+	for _, fieldError := range e.Errors {
+		fmt.Fprintf(&b, "\n- %s: %s", fieldError.Field, fieldError.Message)
+	}
+	return b.String()
+}
+
+// WriteArgs represents the arguments shared by all write tools.
+// It allows waiting for the triggered actions to finish before returning,
+// or only planning the change without applying it.
+type WriteArgs struct {
+	Wait   bool `json:"wait,omitempty" jsonschema:"description=Wait for the triggered actions to finish and return the final state of the resource"`
+	DryRun bool `json:"dry_run,omitempty" jsonschema:"description=Only validate the request and return the planned change without applying it"`
+}
+
+// isDryRun reports whether the change should only be planned, either
+// because it was requested or because the server runs in dry-run mode.
+func (a WriteArgs) isDryRun() bool {
+	return a.DryRun || dryRunMode
+}
+
+// actionTarget describes a resource type write tools run actions on with runAction.
+// Resp is the type check returns the expected resource as, either the response or the hcloud type.
+type actionTarget[R, Resp any] struct {
+	// name is the name of the resource type in errors and the label scope, e.g. "floating ip"
+	name string
+	// actionType is the resource type of the triggered Actions, e.g. floating_ip
+	actionType string
+	// get retrieves the resource and fails if it does not exist
+	get     func(context.Context, int64) (*R, error)
+	labels  func(*R) map[string]string
+	convert func(*R) *Resp
+	// respond returns the result of the tool for the resource, nil if it was deleted, and the triggered Actions
+	respond func(*Resp, []*ActionResponse) any
+}
+
+// runAction looks up a resource and runs the given action on it.
+// check validates the change in advance on a copy of the resource and returns the resource it is expected
+// to result in, or nil if the action deletes it. In dry-run mode, the expected resource is returned as planned
+// change. Changes without Actions are applied immediately, the final state of the resource is then returned
+// right away, otherwise only if waiting for the actions was requested. Deleted resources are not retrieved again.
+func runAction[R, Resp any](ctx context.Context, target actionTarget[R, Resp], id int64, write WriteArgs, command string, immediate bool,
+	check func(context.Context, *R) (*Resp, []string, error),
+	action func(context.Context, *R) ([]*hcloud.Action, error),
+) (*mcpgolang.ToolResponse, error) {
+	return handleResponse(ctx, func() (any, error) {
+		resource, err := target.get(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkScope(target.name, id, target.labels(resource)); err != nil {
+			return nil, err
+		}
+		// check works on a copy, so that the action gets the current state of the resource
+		current := *resource
+		expected, warnings, err := check(ctx, &current)
+		if err != nil {
+			return nil, err
+		}
+
+		if write.isDryRun() {
+			planned := []*ActionResponse{}
+			if !immediate {
+				planned = append(planned, plannedAction(command, ActionResource{ID: id, Type: target.actionType}))
+			}
+			return newPlannedChange(command, target.respond(expected, planned), warnings...), nil
+		}
+
+		actions, err := action(ctx, resource)
+		if err != nil {
+			return nil, err
+		}
+		recordActions(ctx, actions...)
+		if len(actions) > 0 && write.Wait {
+			actions, err = waitForActions(ctx, actions...)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var result *Resp
+		if expected != nil {
+			if len(actions) == 0 || write.Wait {
+				resource, err = target.get(ctx, id)
+				if err != nil {
+					return nil, err
+				}
+			}
+			result = target.convert(resource)
+		}
+		return target.respond(result, toActionResponseList(actions)), nil
+	})
+}
+
+// singleAction adapts an action triggering at most one Action to runAction.
+func singleAction[R any](action func(context.Context, *R) (*hcloud.Action, error)) func(context.Context, *R) ([]*hcloud.Action, error) {
+	return func(ctx context.Context, resource *R) ([]*hcloud.Action, error) {
+		result, err := action(ctx, resource)
+		if err != nil || result == nil {
+			return nil, err
+		}
+		return []*hcloud.Action{result}, nil
+	}
+}
+
+// firstAction returns the only Action of a tool triggering at most one Action, nil if it triggered none.
+func firstAction(actions []*ActionResponse) *ActionResponse {
+	if len(actions) == 0 {
+		return nil
+	}
+	return actions[0]
 }
 
 // Tool represents a tool with a name, description, and handler function.
@@ -32,3 +232,67 @@ type Tool struct {
 	Handler     any
 	Restriction Restriction
 }
+
+// ResourceRef represents a resource referenced by another resource, collapsed to its id and name.
+type ResourceRef struct {
+	ID   int64  `json:"id" jsonschema:"required,description=Unique identifier of the resource"`
+	Name string `json:"name,omitempty" jsonschema:"description=The name of the resource"`
+}
+
+func toResourceRef(id int64, name string) *ResourceRef {
+	return &ResourceRef{ID: id, Name: name}
+}
+
+// DeleteProtection represents the protection settings of resources which can only be protected from deletion.
+type DeleteProtection struct {
+	Delete bool `json:"delete" jsonschema:"required,description=Whether the resource is protected from deletion"`
+}
+
+// DeprecationResponse represents the deprecation of a resource like a server type or an ISO.
+type DeprecationResponse struct {
+	Announced        time.Time `json:"announced" jsonschema:"required,description=Timestamp of when the deprecation was announced"`
+	UnavailableAfter time.Time `json:"unavailable_after" jsonschema:"required,description=Timestamp after which the resource is no longer available"`
+}
+
+func toDeprecationResponse(d *hcloud.DeprecationInfo) *DeprecationResponse {
+	if d == nil {
+		return nil
+	}
+	return &DeprecationResponse{
+		Announced:        d.Announced,
+		UnavailableAfter: d.UnavailableAfter,
+	}
+}
+
+// ipString returns the textual representation of an IP address, empty if it is not set.
+func ipString(ip net.IP) string {
+	if ip == nil {
+		return EmptyString
+	}
+	return ip.String()
+}
+
+// ipNetString returns the CIDR notation of a network, empty if it is not set.
+func ipNetString(ipNet *net.IPNet) string {
+	if ipNet == nil {
+		return EmptyString
+	}
+	return ipNet.String()
+}
+
+// timePtr returns nil for the zero time, which hcloud uses for unset timestamps.
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+// toResponseList converts a list of hcloud resources to their responses.
+func toResponseList[R any, T any](items []R, convert func(R) T) []T {
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		result = append(result, convert(item))
+	}
+	return result
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileEnv is the environment variable with the path of the configuration file.
+const ConfigFileEnv = "MCP_HETZNER_CONFIG"
+
+// Config represents the configuration of the server.
+//
+// Every setting can be given in the YAML configuration file (yaml tag), as environment variable
+// (env tag, also read from a .env file in the working directory) and as command-line flag (flag tag).
+// Flags take precedence over environment variables, which take precedence over the configuration file.
+type Config struct {
+	Restriction Restriction `yaml:"restriction" env:"MCP_HETZNER_RESTRICTION" flag:"restriction" usage:"Restriction of the tools, either of read_only or read_write"`
+	DryRun      bool        `yaml:"dry_run" env:"MCP_HETZNER_DRY_RUN" flag:"dry-run" usage:"Only plan changes of write tools without applying them"`
+
+	Token          string        `yaml:"token" env:"HCLOUD_TOKEN" flag:"token" usage:"Hetzner Cloud API token"`
+	TokenFile      string        `yaml:"token_file" env:"HCLOUD_TOKEN_FILE" flag:"token-file" usage:"Path to a file containing the Hetzner Cloud API token"`
+	Endpoint       string        `yaml:"endpoint" env:"HCLOUD_ENDPOINT" flag:"endpoint" usage:"Endpoint of the Hetzner Cloud API"`
+	RequestTimeout time.Duration `yaml:"request_timeout" env:"MCP_HETZNER_REQUEST_TIMEOUT" flag:"request-timeout" usage:"Timeout of a single request to the Hetzner Cloud API"`
+	ActionTimeout  time.Duration `yaml:"action_timeout" env:"MCP_HETZNER_ACTION_TIMEOUT" flag:"action-timeout" usage:"Maximum time to wait for actions to finish"`
+
+	Projects       []ProjectConfig `yaml:"projects"`
+	DefaultProject string          `yaml:"default_project" env:"MCP_HETZNER_DEFAULT_PROJECT" flag:"default-project" usage:"Project used by tools if no project is given"`
+
+	SnapshotPolicies []SnapshotPolicy `yaml:"snapshot_policies"`
+
+	MaxResponseSize int `yaml:"max_response_size" env:"MCP_HETZNER_MAX_RESPONSE_SIZE" flag:"max-response-size" usage:"Maximum size of a tool response in bytes before it is truncated, 0 disables the limit"`
+
+	AllowTools []string `yaml:"allow_tools" env:"MCP_HETZNER_ALLOW_TOOLS" flag:"allow-tools" usage:"Comma separated list of tool names or glob patterns to register, e.g. get_*"`
+	DenyTools  []string `yaml:"deny_tools" env:"MCP_HETZNER_DENY_TOOLS" flag:"deny-tools" usage:"Comma separated list of tool names or glob patterns never to register, e.g. delete_*"`
+
+	ScopeLabelSelector string `yaml:"scope_label_selector" env:"MCP_HETZNER_SCOPE_LABEL_SELECTOR" flag:"scope-label-selector" usage:"Label selector resources must match to be changed by write tools, e.g. team=platform"`
+	ScopeFilterLists   bool   `yaml:"scope_filter_lists" env:"MCP_HETZNER_SCOPE_FILTER_LISTS" flag:"scope-filter-lists" usage:"Hide resources outside of the label scope from read tools"`
+
+	AuditLog        string `yaml:"audit_log" env:"MCP_HETZNER_AUDIT_LOG" flag:"audit-log" usage:"Path of the audit log file, or stderr to write the audit log to stderr"`
+	AuditMaxSize    int    `yaml:"audit_max_size" env:"MCP_HETZNER_AUDIT_MAX_SIZE" flag:"audit-max-size" usage:"Maximum size of the audit log file in megabytes before it is rotated, 0 disables rotation"`
+	AuditMaxBackups int    `yaml:"audit_max_backups" env:"MCP_HETZNER_AUDIT_MAX_BACKUPS" flag:"audit-max-backups" usage:"Maximum number of rotated audit log files to keep"`
+
+	Transport string `yaml:"transport" env:"MCP_HETZNER_TRANSPORT" flag:"transport" usage:"Transport to serve MCP over, either of stdio, http or sse"`
+	Listen    string `yaml:"listen" env:"MCP_HETZNER_LISTEN" flag:"listen" usage:"Address to listen on for the http and sse transports"`
+
+	AuthFile    string `yaml:"auth_file" env:"MCP_HETZNER_AUTH_FILE" flag:"auth-file" usage:"Path to a YAML file with the credentials of the clients of the http and sse transports"`
+	TLSCert     string `yaml:"tls_cert" env:"MCP_HETZNER_TLS_CERT" flag:"tls-cert" usage:"Path to the TLS certificate to serve the http and sse transports with"`
+	TLSKey      string `yaml:"tls_key" env:"MCP_HETZNER_TLS_KEY" flag:"tls-key" usage:"Path to the TLS key to serve the http and sse transports with"`
+	TLSClientCA string `yaml:"tls_client_ca" env:"MCP_HETZNER_TLS_CLIENT_CA" flag:"tls-client-ca" usage:"Path to the CA to verify client certificates of the http and sse transports with"`
+}
+
+// defaultConfig returns the configuration used for settings which are not set anywhere.
+func defaultConfig() *Config {
+	return &Config{
+		Restriction:     RestrictionReadOnly,
+		RequestTimeout:  time.Minute,
+		ActionTimeout:   10 * time.Minute,
+		MaxResponseSize: 100 * 1024,
+		AuditMaxSize:    100,
+		AuditMaxBackups: 5,
+		Transport:       TransportStdio,
+		Listen:          "127.0.0.1:8080",
+	}
+}
+
+// loadConfig builds the configuration from the defaults, the configuration file,
+// the .env file, the environment and the command-line arguments, and validates it.
+func loadConfig(arguments []string) (*Config, error) {
+	config := defaultConfig()
+
+	flags := flag.NewFlagSet(filepath.Base(os.Args[0]), flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	configFile := flags.String("config", EmptyString, "Path to a YAML configuration file, also read from "+ConfigFileEnv)
+	registerConfigFlags(flags, config)
+	if err := flags.Parse(arguments); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			flags.SetOutput(os.Stderr)
+			flags.Usage()
+		}
+		return nil, err
+	}
+
+	// Variables already set in the environment take precedence over the .env file
+	if cwd, err := os.Getwd(); err == nil {
+		_ = godotenv.Load(filepath.Join(cwd, ".env")) // ignore error
+	}
+
+	if *configFile == EmptyString {
+		*configFile = os.Getenv(ConfigFileEnv)
+	}
+	if *configFile != EmptyString {
+		data, err := os.ReadFile(*configFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read config file: %w", err)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to parse config file %s: %w", *configFile, err)
+		}
+	}
+
+	var errs []error
+	forEachSetting(config, func(field reflect.StructField, value reflect.Value) {
+		if env, ok := os.LookupEnv(field.Tag.Get("env")); ok && field.Tag.Get("env") != EmptyString {
+			if err := setConfigValue(value, env); err != nil {
+				errs = append(errs, fmt.Errorf("invalid value of environment variable %s: %w", field.Tag.Get("env"), err))
+			}
+		}
+	})
+	flags.Visit(func(f *flag.Flag) {
+		forEachSetting(config, func(field reflect.StructField, value reflect.Value) {
+			if field.Tag.Get("flag") == f.Name {
+				if err := setConfigValue(value, f.Value.String()); err != nil {
+					errs = append(errs, fmt.Errorf("invalid value of flag -%s: %w", f.Name, err))
+				}
+			}
+		})
+	})
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// forEachSetting calls fn for every field of the configuration which can be set by flag or environment variable.
+func forEachSetting(config *Config, fn func(field reflect.StructField, value reflect.Value)) {
+	v := reflect.ValueOf(config).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		if field := v.Type().Field(i); field.Tag.Get("flag") != EmptyString {
+			fn(field, v.Field(i))
+		}
+	}
+}
+
+// registerConfigFlags defines a command-line flag for every setting of the configuration.
+// Flags are only parsed here, their values are applied after the configuration file and environment.
+func registerConfigFlags(flags *flag.FlagSet, config *Config) {
+	forEachSetting(config, func(field reflect.StructField, value reflect.Value) {
+		usage := field.Tag.Get("usage")
+		if env := field.Tag.Get("env"); env != EmptyString {
+			usage += " (env " + env + ")"
+		}
+		if value.Kind() == reflect.Bool {
+			flags.Bool(field.Tag.Get("flag"), value.Bool(), usage)
+			return
+		}
+		flags.String(field.Tag.Get("flag"), formatConfigValue(value), usage)
+	})
+}
+
+func formatConfigValue(value reflect.Value) string {
+	switch v := value.Interface().(type) {
+	case []string:
+		return strings.Join(v, ",")
+	case time.Duration:
+		if v == 0 {
+			return EmptyString
+		}
+		return v.String()
+	case int:
+		if v == 0 {
+			return EmptyString
+		}
+	}
+	return fmt.Sprint(value.Interface())
+}
+
+// setConfigValue parses the value of a flag or environment variable into a field of the configuration.
+func setConfigValue(value reflect.Value, s string) error {
+	switch value.Interface().(type) {
+	case []string:
+		value.Set(reflect.ValueOf(splitToolPatterns(s)))
+	case time.Duration:
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(d))
+	case bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case int:
+		n, err := strconv.Atoi(s)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(n))
+	default:
+		value.SetString(s)
+	}
+	return nil
+}
+
+// Validate checks the configuration and reports all problems at once.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Restriction != RestrictionReadOnly && c.Restriction != RestrictionReadWrite {
+		errs = append(errs, fmt.Errorf("invalid restriction %q, must be either of read_only or read_write", c.Restriction))
+	}
+
+	if len(c.Projects) == 0 && c.Token == EmptyString && c.TokenFile == EmptyString {
+		errs = append(errs, fmt.Errorf("no Hetzner Cloud API token set, use -token, -token-file, HCLOUD_TOKEN, a .env file or projects in the config file"))
+	}
+	if c.Endpoint != EmptyString {
+		if u, err := url.Parse(c.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == EmptyString {
+			errs = append(errs, fmt.Errorf("invalid endpoint %q, must be an http or https URL", c.Endpoint))
+		}
+	}
+	if c.RequestTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("request timeout must be positive"))
+	}
+	if c.ActionTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("action timeout must be positive"))
+	}
+
+	projectNames := []string{DefaultProjectName}
+	if len(c.Projects) > 0 {
+		projectNames = nil
+		for _, project := range c.Projects {
+			projectNames = append(projectNames, project.Name)
+		}
+	}
+	policyNames := make(map[string]bool)
+	for i, policy := range c.SnapshotPolicies {
+		if err, ok := policy.Validate().(interface{ Unwrap() []error }); ok {
+			for _, err := range err.Unwrap() {
+				errs = append(errs, fmt.Errorf("snapshot policy %d: %w", i+1, err))
+			}
+		}
+		if policyNames[policy.Name] {
+			errs = append(errs, fmt.Errorf("snapshot policy %d: duplicate name %q", i+1, policy.Name))
+		}
+		policyNames[policy.Name] = true
+		if policy.Project != EmptyString && !slices.Contains(projectNames, policy.Project) {
+			errs = append(errs, fmt.Errorf("snapshot policy %d: project %s is not configured", i+1, policy.Project))
+		}
+	}
+
+	if c.MaxResponseSize < 0 {
+		errs = append(errs, fmt.Errorf("max response size must not be negative"))
+	}
+
+	if err := (ToolFilter{Allow: c.AllowTools, Deny: c.DenyTools}).Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.ScopeLabelSelector != EmptyString {
+		if _, err := newLabelScope(c.ScopeLabelSelector, c.ScopeFilterLists); err != nil {
+			errs = append(errs, fmt.Errorf("invalid scope label selector: %w", err))
+		}
+	}
+
+	if c.AuditMaxSize < 0 || c.AuditMaxBackups < 0 {
+		errs = append(errs, fmt.Errorf("audit log size and backups must not be negative"))
+	}
+	if c.AuditLog != EmptyString && c.AuditLog != AuditStderr && c.AuditMaxSize > 0 && c.AuditMaxBackups == 0 {
+		errs = append(errs, fmt.Errorf("audit log rotation requires at least one backup, set audit max size to 0 to disable rotation"))
+	}
+	if c.Transport != TransportStdio && c.Transport != TransportHTTP && c.Transport != TransportSSE {
+		errs = append(errs, fmt.Errorf("invalid transport %q, must be one of stdio, http or sse", c.Transport))
+	}
+
+	return errors.Join(errs...)
+}
+
+// token returns the Hetzner Cloud API token, read from the token file if no token is set.
+func (c *Config) token() (string, error) {
+	if c.Token != EmptyString {
+		return c.Token, nil
+	}
+	return readTokenFile(c.TokenFile)
+}
+
+// readTokenFile reads an API token from a file, ignoring surrounding whitespace.
+func readTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return EmptyString, fmt.Errorf("failed to read token file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == EmptyString {
+		return EmptyString, fmt.Errorf("token file %s is empty", path)
+	}
+	return token, nil
+}
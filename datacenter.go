@@ -11,31 +11,24 @@ import (
 // It contains the Datacenter ID or Name that is needed to perform the lookup.
 type DatacenterReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The datacenter id or name to be searched"`
-}
-
-type Location struct {
-	ID   int64  `json:"id" jsonschema:"required,description=The location id"`
-	Name string `json:"name" jsonschema:"required,description=The location name"`
-}
-
-type ServerTypeId struct {
-	ID int64 `json:"id" jsonschema:"required,description=The server type id"`
+	ProjectArgs
+	OutputArgs
 }
 
 type DatacenterResponse struct {
 	ID                               int64          `json:"id" jsonschema:"required,description=The datacenter id"`
 	Name                             string         `json:"name" jsonschema:"required,description=The datacenter name"`
 	Description                      string         `json:"description" jsonschema:"description=The datacenter description"`
-	Location                         Location       `json:"location" jsonschema:"required,description=The location of the datacenter"`
-	SupportedServerTypes             []ServerTypeId `json:"supported_server_types" jsonschema:"description=List of supported server types"`
-	AvailableForMigrationServerTypes []ServerTypeId `json:"available_for_migration_server_types" jsonschema:"description=List of available for migration server types"`
-	AvailableServerTypes             []ServerTypeId `json:"available_server_types" jsonschema:"description=List of available server types"`
+	Location                         *ResourceRef   `json:"location" jsonschema:"required,description=The location of the datacenter"`
+	SupportedServerTypes             []*ResourceRef `json:"supported_server_types" jsonschema:"description=List of supported server types"`
+	AvailableForMigrationServerTypes []*ResourceRef `json:"available_for_migration_server_types" jsonschema:"description=List of available for migration server types"`
+	AvailableServerTypes             []*ResourceRef `json:"available_server_types" jsonschema:"description=List of available server types"`
 }
 
-func toServerTypeIdList(serverTypes []*hcloud.ServerType) []ServerTypeId {
-	var result []ServerTypeId
+func toServerTypeRefList(serverTypes []*hcloud.ServerType) []*ResourceRef {
+	result := make([]*ResourceRef, 0, len(serverTypes))
 	for _, serverType := range serverTypes {
-		result = append(result, ServerTypeId{ID: serverType.ID})
+		result = append(result, toServerTypeRef(serverType))
 	}
 	return result
 }
@@ -46,17 +39,21 @@ func toDatacenterResponse(d *hcloud.Datacenter) *DatacenterResponse {
 	}
 
 	return &DatacenterResponse{
-		ID:          d.ID,
-		Name:        d.Name,
-		Description: d.Description,
-		Location: Location{
-			ID:   d.Location.ID,
-			Name: d.Location.Name,
-		},
-		SupportedServerTypes:             toServerTypeIdList(d.ServerTypes.Supported),
-		AvailableForMigrationServerTypes: toServerTypeIdList(d.ServerTypes.AvailableForMigration),
-		AvailableServerTypes:             toServerTypeIdList(d.ServerTypes.Available),
+		ID:                               d.ID,
+		Name:                             d.Name,
+		Description:                      d.Description,
+		Location:                         toLocationRef(d.Location),
+		SupportedServerTypes:             toServerTypeRefList(d.ServerTypes.Supported),
+		AvailableForMigrationServerTypes: toServerTypeRefList(d.ServerTypes.AvailableForMigration),
+		AvailableServerTypes:             toServerTypeRefList(d.ServerTypes.Available),
+	}
+}
+
+func toDatacenterRef(d *hcloud.Datacenter) *ResourceRef {
+	if d == nil {
+		return nil
 	}
+	return toResourceRef(d.ID, d.Name)
 }
 
 // DatacenterTools
@@ -64,9 +61,9 @@ var datacenterTools = []Tool{
 	{
 		Name:        "get_all_datacenters",
 		Description: "Returns all Datacenters objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*DatacenterResponse, error) {
-				result, err := client.Datacenter.All(context.Background())
+		Handler: func(ctx context.Context, _ NoArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() ([]*DatacenterResponse, error) {
+				result, err := hcloudClient(ctx).Datacenter.All(ctx)
 				if err != nil {
 					return nil, err
 				}
@@ -82,9 +79,9 @@ var datacenterTools = []Tool{
 	{
 		Name:        "get_a_datacenter_by_id_or_name",
 		Description: "Retrieves a Datacenter by its ID or Name, Get retrieves a Datacenter by its ID if the input can be parsed as an integer, otherwise it retrieves a Datacenter by its name. If the Datacenter does not exist, nil is returned.",
-		Handler: func(args DatacenterReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*DatacenterResponse, error) {
-				result, _, err := client.Datacenter.Get(context.Background(), args.IDOrName)
+		Handler: func(ctx context.Context, args DatacenterReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*DatacenterResponse, error) {
+				result, _, err := hcloudClient(ctx).Datacenter.Get(ctx, args.IDOrName)
 				if err != nil {
 					return nil, err
 				}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"time"
+)
+
+// ActionStatusPlanned is the status of the actions a write tool returns in dry-run mode.
+const ActionStatusPlanned = "planned"
+
+// dryRunMode forces every write tool into dry-run mode, regardless of its dry_run argument.
+// It is set with the --dry-run flag.
+var dryRunMode bool
+
+// PlannedChange describes the change a write tool would apply.
+// The result has the same shape as the result the tool returns when the change is applied,
+// so operators can compare the planned change with the real one.
+type PlannedChange struct {
+	DryRun    bool     `json:"dry_run" jsonschema:"required,description=Always true, the change was not applied"`
+	Operation string   `json:"operation" jsonschema:"required,description=The operation which would be performed, e.g. create_server"`
+	Warnings  []string `json:"warnings,omitempty" jsonschema:"description=Things worth knowing before applying the change"`
+	Result    any      `json:"result" jsonschema:"required,description=The expected result of the operation"`
+}
+
+func newPlannedChange(operation string, result any, warnings ...string) *PlannedChange {
+	return &PlannedChange{
+		DryRun:    true,
+		Operation: operation,
+		Warnings:  warnings,
+		Result:    result,
+	}
+}
+
+// plannedAction returns the Action a write tool would trigger.
+func plannedAction(command string, resources ...ActionResource) *ActionResponse {
+	return &ActionResponse{
+		Command:   command,
+		Status:    ActionStatusPlanned,
+		Started:   time.Now(),
+		Resources: resources,
+	}
+}
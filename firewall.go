@@ -2,20 +2,17 @@ package main
 
 import (
 	"context"
-	"encoding/base64"
-	"log"
+	"fmt"
 	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	mcpgolang "github.com/metoro-io/mcp-golang"
 )
 
-// An IPNet represents an IP network.
-type IPNet struct {
-	IP   string `json:"ip" jsonschema:"required,description=Network Number, base64 format, example: 0.0.0.0"`
-	Mask string `json:"mask" jsonschema:"required,description=Network Mask, base64 format, example: AAAAAA=="`
-}
-
 // FirewallResourceServer represents a Server to apply a Firewall on.
 type FirewallResourceServer struct {
 	ID int64 `json:"id" jsonschema:"description=Server ID"`
@@ -28,12 +25,12 @@ type FirewallResourceLabelSelector struct {
 
 // FirewallRule represents a Firewall's rules.
 type FirewallRule struct {
-	Direction      string  `json:"direction" jsonschema:"required"`
-	SourceIPs      []IPNet `json:"source_ips"`
-	DestinationIPs []IPNet `json:"destination_ips"`
-	Protocol       string  `json:"protocol" jsonschema:"required"`
-	Port           *string `json:"port,omitempty"`
-	Description    *string `json:"description,omitempty"`
+	Direction      string   `json:"direction" jsonschema:"required,description=Direction of the rule (in/out)"`
+	SourceIPs      []string `json:"source_ips,omitempty" jsonschema:"description=Source networks of incoming rules in CIDR notation (e.g. 10.0.0.0/8 or 0.0.0.0/0 or ::/0). Single addresses are allowed"`
+	DestinationIPs []string `json:"destination_ips,omitempty" jsonschema:"description=Destination networks of outgoing rules in CIDR notation (e.g. 10.0.0.0/8 or 0.0.0.0/0 or ::/0). Single addresses are allowed"`
+	Protocol       string   `json:"protocol" jsonschema:"required,description=Protocol of the rule (tcp/udp/icmp/esp/gre)"`
+	Port           *string  `json:"port,omitempty" jsonschema:"description=Port (e.g. 443) or port range (e.g. 8000-8100) of tcp and udp rules"`
+	Description    *string  `json:"description,omitempty" jsonschema:"description=Description of the rule (at most 255 characters)"`
 }
 
 // FirewallResource represents a resource to apply the new Firewall on.
@@ -47,6 +44,17 @@ type FirewallResource struct {
 // It contains the Firewall ID or Name that is needed to perform the lookup.
 type FirewallReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The firewall id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// FirewallListArgs represents the arguments for listing Firewalls.
+// Firewalls can be filtered by name on top of the label selector.
+type FirewallListArgs struct {
+	Name string `json:"name,omitempty" jsonschema:"description=Only return the firewall with this name"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
 }
 
 // FirewallCreateArgs contains the necessary fields to create a new firewall,
@@ -56,50 +64,250 @@ type FirewallCreateArgs struct {
 	Labels  map[string]string  `json:"labels,omitempty"`
 	Rules   []FirewallRule     `json:"rules"`
 	ApplyTo []FirewallResource `json:"apply_to"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
 }
 
-func convertIPNets(ipnets []IPNet) []net.IPNet {
-	converted := make([]net.IPNet, 0, len(ipnets))
+// FirewallActionArgs represents the arguments required to run an action on a Firewall.
+// It contains the Firewall ID the action is performed on.
+type FirewallActionArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The firewall id"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
 
-	for _, ipnet := range ipnets {
-		ip := net.ParseIP(ipnet.IP)
-		if ip == nil {
-			log.Printf("Invalid IP: %s", ipnet.IP)
-			continue
+// FirewallUpdateArgs represents the arguments required to rename a Firewall or replace its labels.
+type FirewallUpdateArgs struct {
+	ID     int64             `json:"id" jsonschema:"required,description=The firewall id"`
+	Name   string            `json:"name,omitempty" jsonschema:"description=New name of the firewall"`
+	Labels map[string]string `json:"labels,omitempty" jsonschema:"description=New labels of the firewall. Replaces all existing labels"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// FirewallRulesArgs represents the arguments required to replace the rules of a Firewall.
+type FirewallRulesArgs struct {
+	ID    int64          `json:"id" jsonschema:"required,description=The firewall id"`
+	Rules []FirewallRule `json:"rules" jsonschema:"description=The new rules of the firewall. Replaces all existing rules; an empty list removes all rules"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// FirewallRuleArgs represents the arguments required to add a rule to or remove a rule from a Firewall.
+type FirewallRuleArgs struct {
+	ID   int64        `json:"id" jsonschema:"required,description=The firewall id"`
+	Rule FirewallRule `json:"rule" jsonschema:"required,description=The rule to add or remove. Rules are matched by direction; protocol; port and ips"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// FirewallResourcesArgs represents the arguments required to apply a Firewall to resources or remove it from them.
+type FirewallResourcesArgs struct {
+	ID        int64              `json:"id" jsonschema:"required,description=The firewall id"`
+	Resources []FirewallResource `json:"resources" jsonschema:"required,description=The servers and label selectors"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+type FirewallCreateResponse struct {
+	Firewall *FirewallResponse `json:"firewall" jsonschema:"required,description=The created firewall"`
+	Actions  []*ActionResponse `json:"actions" jsonschema:"description=Actions applying the firewall to its resources"`
+}
+
+type FirewallActionResponse struct {
+	Firewall *FirewallResponse `json:"firewall" jsonschema:"description=The firewall the action was performed on; null if it was deleted"`
+	Actions  []*ActionResponse `json:"actions" jsonschema:"description=The triggered actions; empty if the change did not need any"`
+}
+
+type FirewallRuleResponse struct {
+	Direction      string   `json:"direction" jsonschema:"required,description=Direction of the rule either of in or out"`
+	SourceIPs      []string `json:"source_ips" jsonschema:"description=Source networks of incoming rules in CIDR notation"`
+	DestinationIPs []string `json:"destination_ips" jsonschema:"description=Destination networks of outgoing rules in CIDR notation"`
+	Protocol       string   `json:"protocol" jsonschema:"required,description=Protocol of the rule (tcp/udp/icmp/esp/gre)"`
+	Port           *string  `json:"port,omitempty" jsonschema:"description=Port or port range of tcp and udp rules"`
+	Description    *string  `json:"description,omitempty" jsonschema:"description=Description of the rule"`
+}
+
+type FirewallAppliedToResponse struct {
+	Type          string       `json:"type" jsonschema:"required,description=Type of the resource either of server or label_selector"`
+	Server        *ResourceRef `json:"server,omitempty" jsonschema:"description=The server the firewall is applied to"`
+	LabelSelector string       `json:"label_selector,omitempty" jsonschema:"description=The label selector the firewall is applied to"`
+}
+
+type FirewallResponse struct {
+	ID        int64                       `json:"id" jsonschema:"required,description=Unique identifier of the firewall"`
+	Name      string                      `json:"name" jsonschema:"required,description=The name of the firewall"`
+	Rules     []FirewallRuleResponse      `json:"rules" jsonschema:"description=Rules of the firewall"`
+	AppliedTo []FirewallAppliedToResponse `json:"applied_to" jsonschema:"description=Resources the firewall is applied to"`
+	Labels    map[string]string           `json:"labels" jsonschema:"description=User-defined labels for the firewall"`
+	Created   time.Time                   `json:"created" jsonschema:"required,description=Timestamp of when the firewall was created"`
+}
+
+func toFirewallResponse(f *hcloud.Firewall) *FirewallResponse {
+	if f == nil {
+		return nil
+	}
+
+	ipNets := func(ipNets []net.IPNet) []string {
+		result := make([]string, 0, len(ipNets))
+		for _, ipNet := range ipNets {
+			result = append(result, ipNet.String())
+		}
+		return result
+	}
+
+	rules := make([]FirewallRuleResponse, 0, len(f.Rules))
+	for _, r := range f.Rules {
+		rules = append(rules, FirewallRuleResponse{
+			Direction:      string(r.Direction),
+			SourceIPs:      ipNets(r.SourceIPs),
+			DestinationIPs: ipNets(r.DestinationIPs),
+			Protocol:       string(r.Protocol),
+			Port:           r.Port,
+			Description:    r.Description,
+		})
+	}
+
+	appliedTo := make([]FirewallAppliedToResponse, 0, len(f.AppliedTo))
+	for _, a := range f.AppliedTo {
+		resource := FirewallAppliedToResponse{Type: string(a.Type)}
+		if a.Server != nil {
+			resource.Server = toResourceRef(a.Server.ID, EmptyString)
 		}
+		if a.LabelSelector != nil {
+			resource.LabelSelector = a.LabelSelector.Selector
+		}
+		appliedTo = append(appliedTo, resource)
+	}
+
+	return &FirewallResponse{
+		ID:        f.ID,
+		Name:      f.Name,
+		Rules:     rules,
+		AppliedTo: appliedTo,
+		Labels:    f.Labels,
+		Created:   f.Created,
+	}
+}
+
+// maxFirewallRuleDescription is the maximum length of a rule description supported by the Hetzner Cloud API.
+const maxFirewallRuleDescription = 255
+
+var firewallRuleProtocols = []hcloud.FirewallRuleProtocol{
+	hcloud.FirewallRuleProtocolTCP,
+	hcloud.FirewallRuleProtocolUDP,
+	hcloud.FirewallRuleProtocolICMP,
+	hcloud.FirewallRuleProtocolESP,
+	hcloud.FirewallRuleProtocolGRE,
+}
 
-		maskBytes, err := base64.StdEncoding.DecodeString(ipnet.Mask)
+// convertIPNets parses networks in CIDR notation. Single IP addresses become networks of one address.
+func convertIPNets(field string, values []string, errs *ValidationError) []net.IPNet {
+	converted := make([]net.IPNet, 0, len(values))
+
+	for i, value := range values {
+		value = strings.TrimSpace(value)
+		if ip := net.ParseIP(value); ip != nil {
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			bits := len(ip) * 8
+			converted = append(converted, net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		ipNet, err := parseIPRange("network", value)
 		if err != nil {
-			log.Printf("Invalid Mask (base64 decode failed): %s", ipnet.Mask)
+			errs.add(fmt.Sprintf("%s[%d]", field, i), "%s", err)
 			continue
 		}
+		converted = append(converted, *ipNet)
+	}
 
-		mask := net.IPMask(maskBytes)
-		if len(mask) != net.IPv4len && len(mask) != net.IPv6len {
-			log.Printf("Invalid Mask length: %d", len(mask))
-			continue
+	return converted
+}
+
+// checkFirewallPort validates the port (e.g. 443) or port range (e.g. 8000-8100) of a rule.
+func checkFirewallPort(port string) error {
+	first, last, isRange := strings.Cut(port, "-")
+	from, err := strconv.Atoi(first)
+	valid := err == nil && from >= 1 && from <= 65535
+	if isRange {
+		to, err := strconv.Atoi(last)
+		valid = valid && err == nil && to > from && to <= 65535
+	}
+	if !valid {
+		return fmt.Errorf("invalid port %q, must be a port between 1 and 65535 (e.g. 443) or an ascending port range (e.g. 8000-8100)", port)
+	}
+	return nil
+}
+
+// convertRule converts and validates a rule. Problems are added to errs with field as path of the rule.
+func convertRule(field string, rule FirewallRule, errs *ValidationError) hcloud.FirewallRule {
+	converted := hcloud.FirewallRule{
+		Direction:      hcloud.FirewallRuleDirection(rule.Direction),
+		SourceIPs:      convertIPNets(field+".source_ips", rule.SourceIPs, errs),
+		DestinationIPs: convertIPNets(field+".destination_ips", rule.DestinationIPs, errs),
+		Protocol:       hcloud.FirewallRuleProtocol(rule.Protocol),
+		Port:           rule.Port,
+		Description:    rule.Description,
+	}
+
+	switch converted.Direction {
+	case hcloud.FirewallRuleDirectionIn:
+		if len(rule.SourceIPs) == 0 {
+			errs.add(field+".source_ips", "incoming rules require at least one source network")
+		}
+		if len(rule.DestinationIPs) > 0 {
+			errs.add(field+".destination_ips", "incoming rules have no destination networks")
+		}
+	case hcloud.FirewallRuleDirectionOut:
+		if len(rule.DestinationIPs) == 0 {
+			errs.add(field+".destination_ips", "outgoing rules require at least one destination network")
 		}
+		if len(rule.SourceIPs) > 0 {
+			errs.add(field+".source_ips", "outgoing rules have no source networks")
+		}
+	default:
+		errs.add(field+".direction", "invalid direction %q, must be one of in, out", rule.Direction)
+	}
 
-		converted = append(converted, net.IPNet{
-			IP:   ip,
-			Mask: mask,
-		})
+	switch converted.Protocol {
+	case hcloud.FirewallRuleProtocolTCP, hcloud.FirewallRuleProtocolUDP:
+		if rule.Port == nil {
+			errs.add(field+".port", "%s rules require a port or port range", rule.Protocol)
+		} else if err := checkFirewallPort(*rule.Port); err != nil {
+			errs.add(field+".port", "%s", err)
+		}
+	default:
+		if !slices.Contains(firewallRuleProtocols, converted.Protocol) {
+			errs.add(field+".protocol", "invalid protocol %q, must be one of tcp, udp, icmp, esp, gre", rule.Protocol)
+		} else if rule.Port != nil {
+			errs.add(field+".port", "%s rules have no port", rule.Protocol)
+		}
+	}
+
+	if rule.Description != nil && len(*rule.Description) > maxFirewallRuleDescription {
+		errs.add(field+".description", "must be at most %d characters", maxFirewallRuleDescription)
 	}
 
 	return converted
 }
 
-func convertRules(rules []FirewallRule) []hcloud.FirewallRule {
+// convertRules converts and validates rules. Problems are added to errs with field as path of the rules.
+func convertRules(field string, rules []FirewallRule, errs *ValidationError) []hcloud.FirewallRule {
 	converted := make([]hcloud.FirewallRule, len(rules))
 
 	for i, rule := range rules {
-		converted[i] = hcloud.FirewallRule{
-			Direction:      hcloud.FirewallRuleDirection(rule.Direction),
-			SourceIPs:      convertIPNets(rule.SourceIPs),
-			DestinationIPs: convertIPNets(rule.DestinationIPs),
-			Protocol:       hcloud.FirewallRuleProtocol(rule.Protocol),
-			Port:           rule.Port,
-			Description:    rule.Description,
+		ruleField := fmt.Sprintf("%s[%d]", field, i)
+		converted[i] = convertRule(ruleField, rule, errs)
+		if j := slices.IndexFunc(converted[:i], func(r hcloud.FirewallRule) bool { return sameFirewallRule(r, converted[i]) }); j >= 0 {
+			errs.add(ruleField, "duplicate of %s[%d]", field, j)
 		}
 	}
 
@@ -122,29 +330,173 @@ func convertLabelSelector(selector FirewallResourceLabelSelector) *hcloud.Firewa
 	return &firewallResourceServer
 }
 
-func convertApplyTo(resources []FirewallResource) []hcloud.FirewallResource {
+// convertApplyTo converts and validates resources. Problems are added to errs with field as path of the resources.
+func convertApplyTo(field string, resources []FirewallResource, errs *ValidationError) []hcloud.FirewallResource {
 	converted := make([]hcloud.FirewallResource, len(resources))
 
 	for i, resource := range resources {
+		resourceField := fmt.Sprintf("%s[%d]", field, i)
 		converted[i] = hcloud.FirewallResource{
-			Type:          hcloud.FirewallResourceType(resource.Type),
-			Server:        convertServerResource(resource.Server),
-			LabelSelector: convertLabelSelector(resource.LabelSelector),
+			Type: hcloud.FirewallResourceType(resource.Type),
+		}
+		switch converted[i].Type {
+		case hcloud.FirewallResourceTypeServer:
+			if resource.Server.ID == 0 {
+				errs.add(resourceField+".server.id", "server resources require a server id")
+			}
+			converted[i].Server = convertServerResource(resource.Server)
+		case hcloud.FirewallResourceTypeLabelSelector:
+			if resource.LabelSelector.Selector == EmptyString {
+				errs.add(resourceField+".label_selector.selector", "label_selector resources require a selector")
+			} else if _, err := parseLabelSelector(resource.LabelSelector.Selector); err != nil {
+				errs.add(resourceField+".label_selector.selector", "%s", err)
+			}
+			converted[i].LabelSelector = convertLabelSelector(resource.LabelSelector)
+		default:
+			errs.add(resourceField+".type", "invalid type %q, must be one of server, label_selector", resource.Type)
 		}
 	}
 
 	return converted
 }
 
+// sameFirewallRule reports whether two rules match the same traffic. Descriptions are ignored.
+func sameFirewallRule(a, b hcloud.FirewallRule) bool {
+	ipNets := func(ipNets []net.IPNet) []string {
+		result := make([]string, 0, len(ipNets))
+		for _, ipNet := range ipNets {
+			result = append(result, ipNet.String())
+		}
+		slices.Sort(result)
+		return result
+	}
+	return a.Direction == b.Direction && a.Protocol == b.Protocol &&
+		(a.Port == nil) == (b.Port == nil) && (a.Port == nil || *a.Port == *b.Port) &&
+		slices.Equal(ipNets(a.SourceIPs), ipNets(b.SourceIPs)) &&
+		slices.Equal(ipNets(a.DestinationIPs), ipNets(b.DestinationIPs))
+}
+
+// indexFirewallResource returns the index of the resource in the resources a Firewall is applied to, -1 if it is not applied to it.
+// Label selectors also match their version narrowed down to the label scope.
+func indexFirewallResource(appliedTo []hcloud.FirewallResource, resource hcloud.FirewallResource) int {
+	return slices.IndexFunc(appliedTo, func(r hcloud.FirewallResource) bool {
+		if r.Type != resource.Type {
+			return false
+		}
+		switch {
+		case r.Server != nil && resource.Server != nil:
+			return r.Server.ID == resource.Server.ID
+		case r.LabelSelector != nil && resource.LabelSelector != nil:
+			return r.LabelSelector.Selector == resource.LabelSelector.Selector ||
+				r.LabelSelector.Selector == scopeLabelSelector(resource.LabelSelector.Selector)
+		}
+		return false
+	})
+}
+
+// scopeApplyTo makes sure a Firewall is only applied to resources inside the label scope.
+// Servers must match the scope and label selectors are narrowed down to the scope.
+func scopeApplyTo(ctx context.Context, resources []hcloud.FirewallResource) ([]hcloud.FirewallResource, error) {
+	if scope == nil {
+		return resources, nil
+	}
+	for _, resource := range resources {
+		switch resource.Type {
+		case hcloud.FirewallResourceTypeServer:
+			server, err := getServer(ctx, resource.Server.ID)
+			if err != nil {
+				return nil, err
+			}
+			if err := checkScope("server", server.ID, server.Labels); err != nil {
+				return nil, err
+			}
+		case hcloud.FirewallResourceTypeLabelSelector:
+			resource.LabelSelector.Selector = scopeLabelSelector(resource.LabelSelector.Selector)
+		}
+	}
+	return resources, nil
+}
+
+// planFirewallCreate validates the options for creating a Firewall and returns the planned change.
+func planFirewallCreate(ctx context.Context, opts hcloud.FirewallCreateOpts) (*PlannedChange, error) {
+	existing, _, err := hcloudClient(ctx).Firewall.GetByName(ctx, opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("firewall %s already exists", opts.Name)
+	}
+
+	actions := []*ActionResponse{}
+	for _, resource := range opts.ApplyTo {
+		switch resource.Type {
+		case hcloud.FirewallResourceTypeServer:
+			server, err := getServer(ctx, resource.Server.ID)
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, plannedAction("apply_firewall", ActionResource{ID: server.ID, Type: string(hcloud.ActionResourceTypeServer)}))
+		case hcloud.FirewallResourceTypeLabelSelector:
+			actions = append(actions, plannedAction("apply_firewall"))
+		}
+	}
+
+	return newPlannedChange("create_firewall", &FirewallCreateResponse{
+		Firewall: toFirewallResponse(&hcloud.Firewall{
+			Name:      opts.Name,
+			Labels:    opts.Labels,
+			Created:   time.Now(),
+			Rules:     opts.Rules,
+			AppliedTo: opts.ApplyTo,
+		}),
+		Actions: actions,
+	}), nil
+}
+
+// getFirewall retrieves a Firewall by its ID and fails if the Firewall does not exist.
+func getFirewall(ctx context.Context, id int64) (*hcloud.Firewall, error) {
+	firewall, _, err := hcloudClient(ctx).Firewall.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if firewall == nil {
+		return nil, fmt.Errorf("firewall %d not found", id)
+	}
+	return firewall, nil
+}
+
+// firewallTarget runs actions on Firewalls with runAction.
+var firewallTarget = actionTarget[hcloud.Firewall, hcloud.Firewall]{
+	name:       "firewall",
+	actionType: "firewall",
+	get:        getFirewall,
+	labels:     func(firewall *hcloud.Firewall) map[string]string { return firewall.Labels },
+	convert:    func(firewall *hcloud.Firewall) *hcloud.Firewall { return firewall },
+	respond: func(firewall *hcloud.Firewall, actions []*ActionResponse) any {
+		return &FirewallActionResponse{Firewall: toFirewallResponse(firewall), Actions: actions}
+	},
+}
+
 // FirewallTools
 var firewallTools = []Tool{
 	{
 		Name:        "get_all_firewalls",
-		Description: "Returns all Firewalls objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.Firewall, error) {
-				result, err := client.Firewall.All(context.Background())
-				return result, err
+		Description: "Returns a page of Firewalls, optionally filtered by name and label selector.",
+		Handler: func(ctx context.Context, args FirewallListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*FirewallResponse], error) {
+				listOpts, err := args.listOpts()
+				if err != nil {
+					return nil, err
+				}
+				opts := hcloud.FirewallListOpts{
+					ListOpts: listOpts,
+					Name:     args.Name,
+				}
+				result, resp, err := hcloudClient(ctx).Firewall.List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return newListResponse(toResponseList(result, toFirewallResponse), resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -152,10 +504,16 @@ var firewallTools = []Tool{
 	{
 		Name:        "get_a_firewall_by_id_or_name",
 		Description: "Retrieves a Firewall by its ID or Name, Get retrieves a Firewall by its ID if the input can be parsed as an integer, otherwise it retrieves a Firewall by its name. If the Firewall does not exist, nil is returned.",
-		Handler: func(args FirewallReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.Firewall, error) {
-				result, _, err := client.Firewall.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args FirewallReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*FirewallResponse, error) {
+				result, _, err := hcloudClient(ctx).Firewall.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return toFirewallResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -163,17 +521,269 @@ var firewallTools = []Tool{
 	{
 		Name:        "create_a_firewall",
 		Description: "Create a new Firewall",
-		Handler: func(args FirewallCreateArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (hcloud.FirewallCreateResult, error) {
-				result, _, err := client.Firewall.Create(context.Background(), hcloud.FirewallCreateOpts{
+		Handler: func(ctx context.Context, args FirewallCreateArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				// Validate all arguments first, a firewall with partial rules might allow too much or too little
+				var errs ValidationError
+				if args.Name == EmptyString {
+					errs.add("name", "missing firewall name")
+				}
+				rules := convertRules("rules", args.Rules, &errs)
+				applyTo := convertApplyTo("apply_to", args.ApplyTo, &errs)
+				if err := errs.err(); err != nil {
+					return nil, err
+				}
+				labels, err := scopeLabels(args.Labels)
+				if err != nil {
+					return nil, err
+				}
+				applyTo, err = scopeApplyTo(ctx, applyTo)
+				if err != nil {
+					return nil, err
+				}
+				opts := hcloud.FirewallCreateOpts{
 					Name:    args.Name,
-					Labels:  args.Labels,
-					Rules:   convertRules(args.Rules),
-					ApplyTo: convertApplyTo(args.ApplyTo),
-				})
-				return result, err
+					Labels:  labels,
+					Rules:   rules,
+					ApplyTo: applyTo,
+				}
+				if args.isDryRun() {
+					return planFirewallCreate(ctx, opts)
+				}
+				result, _, err := hcloudClient(ctx).Firewall.Create(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				recordActions(ctx, result.Actions...)
+				if args.Wait {
+					result.Actions, err = waitForActions(ctx, result.Actions...)
+					if err != nil {
+						return nil, err
+					}
+					result.Firewall, _, err = hcloudClient(ctx).Firewall.GetByID(ctx, result.Firewall.ID)
+					if err != nil {
+						return nil, err
+					}
+				}
+				return &FirewallCreateResponse{
+					Firewall: toFirewallResponse(result.Firewall),
+					Actions:  toActionResponseList(result.Actions),
+				}, nil
 			})
 		},
 		Restriction: RestrictionReadWrite,
 	},
+	{
+		Name:        "update_a_firewall",
+		Description: "Renames a Firewall or replaces its labels.",
+		Handler: func(ctx context.Context, args FirewallUpdateArgs) (*mcpgolang.ToolResponse, error) {
+			var opts hcloud.FirewallUpdateOpts
+			return runAction(ctx, firewallTarget, args.ID, args.WriteArgs, "update_firewall", true,
+				func(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Firewall, []string, error) {
+					if args.Name == EmptyString && args.Labels == nil {
+						return nil, nil, fmt.Errorf("either name or labels is required")
+					}
+					if args.Name != EmptyString {
+						opts.Name = args.Name
+						firewall.Name = args.Name
+					}
+					if args.Labels != nil {
+						labels, err := scopeLabels(args.Labels)
+						if err != nil {
+							return nil, nil, err
+						}
+						opts.Labels = labels
+						firewall.Labels = labels
+					}
+					return firewall, nil, nil
+				},
+				func(ctx context.Context, firewall *hcloud.Firewall) ([]*hcloud.Action, error) {
+					_, _, err := hcloudClient(ctx).Firewall.Update(ctx, firewall, opts)
+					return nil, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "set_firewall_rules",
+		Description: "Replaces all rules of a Firewall. The new rules take effect on all resources the firewall is applied to.",
+		Handler: func(ctx context.Context, args FirewallRulesArgs) (*mcpgolang.ToolResponse, error) {
+			var errs ValidationError
+			rules := convertRules("rules", args.Rules, &errs)
+			return runAction(ctx, firewallTarget, args.ID, args.WriteArgs, "set_firewall_rules", false,
+				func(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Firewall, []string, error) {
+					if err := errs.err(); err != nil {
+						return nil, nil, err
+					}
+					var warnings []string
+					if len(rules) == 0 && len(firewall.AppliedTo) > 0 {
+						warnings = append(warnings, fmt.Sprintf("firewall %d will have no rules, all incoming traffic to its resources is blocked", firewall.ID))
+					}
+					firewall.Rules = rules
+					return firewall, warnings, nil
+				},
+				func(ctx context.Context, firewall *hcloud.Firewall) ([]*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Firewall.SetRules(ctx, firewall, hcloud.FirewallSetRulesOpts{Rules: rules})
+					return result, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "add_firewall_rule",
+		Description: "Adds a rule to a Firewall and keeps its other rules.",
+		Handler: func(ctx context.Context, args FirewallRuleArgs) (*mcpgolang.ToolResponse, error) {
+			var rules []hcloud.FirewallRule
+			return runAction(ctx, firewallTarget, args.ID, args.WriteArgs, "set_firewall_rules", false,
+				func(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Firewall, []string, error) {
+					var errs ValidationError
+					rule := convertRule("rule", args.Rule, &errs)
+					if err := errs.err(); err != nil {
+						return nil, nil, err
+					}
+					if slices.ContainsFunc(firewall.Rules, func(r hcloud.FirewallRule) bool { return sameFirewallRule(r, rule) }) {
+						return nil, nil, fmt.Errorf("firewall %d already has this rule", firewall.ID)
+					}
+					rules = append(slices.Clone(firewall.Rules), rule)
+					firewall.Rules = rules
+					return firewall, nil, nil
+				},
+				func(ctx context.Context, firewall *hcloud.Firewall) ([]*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Firewall.SetRules(ctx, firewall, hcloud.FirewallSetRulesOpts{Rules: rules})
+					return result, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "remove_firewall_rule",
+		Description: "Removes a rule from a Firewall and keeps its other rules. The rule is matched by direction, protocol, port and ips.",
+		Handler: func(ctx context.Context, args FirewallRuleArgs) (*mcpgolang.ToolResponse, error) {
+			var rules []hcloud.FirewallRule
+			return runAction(ctx, firewallTarget, args.ID, args.WriteArgs, "set_firewall_rules", false,
+				func(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Firewall, []string, error) {
+					var errs ValidationError
+					rule := convertRule("rule", args.Rule, &errs)
+					if err := errs.err(); err != nil {
+						return nil, nil, err
+					}
+					i := slices.IndexFunc(firewall.Rules, func(r hcloud.FirewallRule) bool { return sameFirewallRule(r, rule) })
+					if i < 0 {
+						return nil, nil, fmt.Errorf("firewall %d has no such rule", firewall.ID)
+					}
+					rules = slices.Delete(slices.Clone(firewall.Rules), i, i+1)
+					var warnings []string
+					if len(rules) == 0 && len(firewall.AppliedTo) > 0 {
+						warnings = append(warnings, fmt.Sprintf("firewall %d will have no rules, all incoming traffic to its resources is blocked", firewall.ID))
+					}
+					firewall.Rules = rules
+					return firewall, warnings, nil
+				},
+				func(ctx context.Context, firewall *hcloud.Firewall) ([]*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Firewall.SetRules(ctx, firewall, hcloud.FirewallSetRulesOpts{Rules: rules})
+					return result, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "apply_firewall_to_resources",
+		Description: "Applies a Firewall to servers and label selectors. Label selectors apply it to all matching servers, also those created later.",
+		Handler: func(ctx context.Context, args FirewallResourcesArgs) (*mcpgolang.ToolResponse, error) {
+			var resources []hcloud.FirewallResource
+			return runAction(ctx, firewallTarget, args.ID, args.WriteArgs, "apply_firewall", false,
+				func(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Firewall, []string, error) {
+					var errs ValidationError
+					if len(args.Resources) == 0 {
+						errs.add("resources", "at least one resource is required")
+					}
+					resources = convertApplyTo("resources", args.Resources, &errs)
+					if err := errs.err(); err != nil {
+						return nil, nil, err
+					}
+					var err error
+					resources, err = scopeApplyTo(ctx, resources)
+					if err != nil {
+						return nil, nil, err
+					}
+					appliedTo := slices.Clone(firewall.AppliedTo)
+					for _, resource := range resources {
+						if resource.Type == hcloud.FirewallResourceTypeServer {
+							if _, err := getServer(ctx, resource.Server.ID); err != nil {
+								return nil, nil, err
+							}
+						}
+						if indexFirewallResource(appliedTo, resource) >= 0 {
+							return nil, nil, fmt.Errorf("firewall %d is already applied to this %s", firewall.ID, resource.Type)
+						}
+						appliedTo = append(appliedTo, resource)
+					}
+					firewall.AppliedTo = appliedTo
+					return firewall, nil, nil
+				},
+				func(ctx context.Context, firewall *hcloud.Firewall) ([]*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Firewall.ApplyResources(ctx, firewall, resources)
+					return result, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "remove_firewall_from_resources",
+		Description: "Removes a Firewall from servers and label selectors it is applied to.",
+		Handler: func(ctx context.Context, args FirewallResourcesArgs) (*mcpgolang.ToolResponse, error) {
+			var resources []hcloud.FirewallResource
+			return runAction(ctx, firewallTarget, args.ID, args.WriteArgs, "remove_firewall", false,
+				func(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Firewall, []string, error) {
+					var errs ValidationError
+					if len(args.Resources) == 0 {
+						errs.add("resources", "at least one resource is required")
+					}
+					requested := convertApplyTo("resources", args.Resources, &errs)
+					if err := errs.err(); err != nil {
+						return nil, nil, err
+					}
+					appliedTo := slices.Clone(firewall.AppliedTo)
+					resources = nil
+					for _, resource := range requested {
+						i := indexFirewallResource(appliedTo, resource)
+						if i < 0 {
+							return nil, nil, fmt.Errorf("firewall %d is not applied to this %s", firewall.ID, resource.Type)
+						}
+						// Remove the resource as it is applied, label selectors may have been narrowed down to the scope
+						resources = append(resources, hcloud.FirewallResource{
+							Type:          appliedTo[i].Type,
+							Server:        appliedTo[i].Server,
+							LabelSelector: appliedTo[i].LabelSelector,
+						})
+						appliedTo = slices.Delete(appliedTo, i, i+1)
+					}
+					firewall.AppliedTo = appliedTo
+					return firewall, nil, nil
+				},
+				func(ctx context.Context, firewall *hcloud.Firewall) ([]*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Firewall.RemoveResources(ctx, firewall, resources)
+					return result, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_a_firewall",
+		Description: "Deletes a Firewall. It must not be applied to any resource. It cannot be undone.",
+		Handler: func(ctx context.Context, args FirewallActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, firewallTarget, args.ID, args.WriteArgs, "delete_firewall", true,
+				func(ctx context.Context, firewall *hcloud.Firewall) (*hcloud.Firewall, []string, error) {
+					if len(firewall.AppliedTo) > 0 {
+						return nil, nil, fmt.Errorf("firewall %d is applied to %d resources, remove it from them first", firewall.ID, len(firewall.AppliedTo))
+					}
+					return nil, nil, nil
+				},
+				func(ctx context.Context, firewall *hcloud.Firewall) ([]*hcloud.Action, error) {
+					_, err := hcloudClient(ctx).Firewall.Delete(ctx, firewall)
+					return nil, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
 }
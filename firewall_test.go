@@ -0,0 +1,212 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// errorFields returns the fields of the validation errors in order.
+func errorFields(errs ValidationError) []string {
+	fields := make([]string, 0, len(errs.Errors))
+	for _, fieldError := range errs.Errors {
+		fields = append(fields, fieldError.Field)
+	}
+	return fields
+}
+
+func TestConvertIPNets(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []string
+		want       []string
+		wantErrors []FieldError
+	}{
+		{
+			name:   "networks",
+			values: []string{"10.0.0.0/8", "0.0.0.0/0", "::/0", "2001:db8::/32"},
+			want:   []string{"10.0.0.0/8", "0.0.0.0/0", "::/0", "2001:db8::/32"},
+		},
+		{
+			name:   "single addresses",
+			values: []string{"203.0.113.1", " 2001:db8::1 "},
+			want:   []string{"203.0.113.1/32", "2001:db8::1/128"},
+		},
+		{
+			name:   "not the network address",
+			values: []string{"10.0.0.0/8", "10.0.0.1/8"},
+			want:   []string{"10.0.0.0/8"},
+			wantErrors: []FieldError{{
+				Field:   "source_ips[1]",
+				Message: `invalid network "10.0.0.1/8", 10.0.0.1 is not the first address of the range, use 10.0.0.0/8`,
+			}},
+		},
+		{
+			name:   "invalid values",
+			values: []string{"example.com", "10.0.0.0/33", "203.0.113.1"},
+			want:   []string{"203.0.113.1/32"},
+			wantErrors: []FieldError{
+				{Field: "source_ips[0]", Message: `invalid network "example.com", must be in CIDR notation (e.g. 10.0.0.0/16)`},
+				{Field: "source_ips[1]", Message: `invalid network "10.0.0.0/33", must be in CIDR notation (e.g. 10.0.0.0/16)`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs ValidationError
+			converted := convertIPNets("source_ips", tt.values, &errs)
+			got := make([]string, 0, len(converted))
+			for _, ipNet := range converted {
+				got = append(got, ipNet.String())
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("convertIPNets() = %v, want %v", got, tt.want)
+			}
+			if !slices.Equal(errs.Errors, tt.wantErrors) {
+				t.Errorf("convertIPNets() errors = %v, want %v", errs.Errors, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestCheckFirewallPort(t *testing.T) {
+	tests := []struct {
+		port  string
+		valid bool
+	}{
+		{"443", true},
+		{"1", true},
+		{"65535", true},
+		{"8000-8100", true},
+		{"1-65535", true},
+		{"", false},
+		{"0", false},
+		{"65536", false},
+		{"https", false},
+		{"100-100", false},
+		{"200-100", false},
+		{"80-", false},
+		{"-80", false},
+		{"1-65536", false},
+		{"80-90-100", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.port, func(t *testing.T) {
+			err := checkFirewallPort(tt.port)
+			if (err == nil) != tt.valid {
+				t.Errorf("checkFirewallPort(%q) = %v, want valid %t", tt.port, err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestConvertRules(t *testing.T) {
+	tests := []struct {
+		name       string
+		rules      []FirewallRule
+		wantFields []string
+	}{
+		{
+			name: "valid rules",
+			rules: []FirewallRule{
+				{Direction: "in", SourceIPs: []string{"0.0.0.0/0", "::/0"}, Protocol: "tcp", Port: hcloud.Ptr("443")},
+				{Direction: "in", SourceIPs: []string{"10.0.0.0/8"}, Protocol: "udp", Port: hcloud.Ptr("8000-8100")},
+				{Direction: "out", DestinationIPs: []string{"203.0.113.1"}, Protocol: "icmp"},
+			},
+		},
+		{
+			name: "missing and misplaced networks",
+			rules: []FirewallRule{
+				{Direction: "in", DestinationIPs: []string{"10.0.0.0/8"}, Protocol: "icmp"},
+				{Direction: "out", SourceIPs: []string{"10.0.0.0/8"}, Protocol: "icmp"},
+			},
+			wantFields: []string{
+				"rules[0].source_ips", "rules[0].destination_ips",
+				"rules[1].destination_ips", "rules[1].source_ips",
+			},
+		},
+		{
+			name: "invalid direction protocol and ports",
+			rules: []FirewallRule{
+				{Direction: "inbound", SourceIPs: []string{"10.0.0.0/8"}, Protocol: "icmp"},
+				{Direction: "in", SourceIPs: []string{"10.0.0.0/8"}, Protocol: "sctp"},
+				{Direction: "in", SourceIPs: []string{"10.0.0.0/8"}, Protocol: "tcp"},
+				{Direction: "in", SourceIPs: []string{"10.0.0.0/8"}, Protocol: "udp", Port: hcloud.Ptr("0")},
+				{Direction: "in", SourceIPs: []string{"10.0.0.0/8"}, Protocol: "gre", Port: hcloud.Ptr("80")},
+				{Direction: "in", SourceIPs: []string{"10.0.0.1/8"}, Protocol: "esp", Description: hcloud.Ptr(strings.Repeat("a", 256))},
+			},
+			wantFields: []string{
+				"rules[0].direction",
+				"rules[1].protocol",
+				"rules[2].port",
+				"rules[3].port",
+				"rules[4].port",
+				"rules[5].source_ips[0]", "rules[5].description",
+			},
+		},
+		{
+			name: "duplicates in different notations",
+			rules: []FirewallRule{
+				{Direction: "in", SourceIPs: []string{"203.0.113.1", "10.0.0.0/8"}, Protocol: "tcp", Port: hcloud.Ptr("22")},
+				{Direction: "in", SourceIPs: []string{"10.0.0.0/8", "203.0.113.1/32"}, Protocol: "tcp", Port: hcloud.Ptr("22")},
+				{Direction: "in", SourceIPs: []string{"10.0.0.0/8", "203.0.113.1/32"}, Protocol: "tcp", Port: hcloud.Ptr("23")},
+			},
+			wantFields: []string{"rules[1]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs ValidationError
+			converted := convertRules("rules", tt.rules, &errs)
+			if len(converted) != len(tt.rules) {
+				t.Errorf("convertRules() returned %d rules, want %d", len(converted), len(tt.rules))
+			}
+			if got := errorFields(errs); !slices.Equal(got, tt.wantFields) {
+				t.Errorf("convertRules() error fields = %v, want %v\n%v", got, tt.wantFields, errs.Errors)
+			}
+		})
+	}
+}
+
+func TestConvertApplyTo(t *testing.T) {
+	var errs ValidationError
+	convertApplyTo("apply_to", []FirewallResource{
+		{Type: "server", Server: FirewallResourceServer{ID: 2}},
+		{Type: "label_selector", LabelSelector: FirewallResourceLabelSelector{Selector: "role=web"}},
+		{Type: "server"},
+		{Type: "label_selector"},
+		{Type: "load_balancer"},
+	}, &errs)
+
+	want := []string{"apply_to[2].server.id", "apply_to[3].label_selector.selector", "apply_to[4].type"}
+	if got := errorFields(errs); !slices.Equal(got, want) {
+		t.Errorf("convertApplyTo() error fields = %v, want %v", got, want)
+	}
+}
+
+func TestValidationErrorText(t *testing.T) {
+	var errs ValidationError
+	if err := errs.err(); err != nil {
+		t.Fatalf("err() = %v, want nil without errors", err)
+	}
+
+	errs.add("name", "missing firewall name")
+	if got, want := errs.err().Error(), "1 invalid argument, nothing was changed:\n- name: missing firewall name"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+
+	convertRules("rules", []FirewallRule{
+		{Direction: "in", SourceIPs: []string{"10.0.0.1/8"}, Protocol: "tcp", Port: hcloud.Ptr("70000")},
+	}, &errs)
+	convertApplyTo("apply_to", []FirewallResource{{Type: "server"}}, &errs)
+	want := `4 invalid arguments, nothing was changed:
+- name: missing firewall name
+- rules[0].source_ips[0]: invalid network "10.0.0.1/8", 10.0.0.1 is not the first address of the range, use 10.0.0.0/8
+- rules[0].port: invalid port "70000", must be a port between 1 and 65535 (e.g. 443) or an ascending port range (e.g. 8000-8100)
+- apply_to[0].server.id: server resources require a server id`
+	if got := errs.err().Error(); got != want {
+		t.Errorf("Error() =\n%s\nwant\n%s", got, want)
+	}
+}
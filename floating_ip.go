@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	mcpgolang "github.com/metoro-io/mcp-golang"
@@ -11,17 +13,244 @@ import (
 // It contains the FloatingIP ID or Name that is needed to perform the lookup.
 type FloatingIPReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The Floating IP id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// FloatingIPListArgs represents the arguments for listing FloatingIPs.
+// FloatingIPs can be filtered by name on top of the label selector.
+type FloatingIPListArgs struct {
+	Name string `json:"name,omitempty" jsonschema:"description=Only return the floating ip with this name"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// FloatingIPCreateArgs represents the arguments required to create a Floating IP.
+// The Floating IP is either created in a home location or assigned to a server right away.
+type FloatingIPCreateArgs struct {
+	Type         string            `json:"type" jsonschema:"required,description=The ip type (ipv4/ipv6)"`
+	Name         string            `json:"name,omitempty" jsonschema:"description=The floating ip name"`
+	Description  string            `json:"description,omitempty" jsonschema:"description=The floating ip description"`
+	HomeLocation string            `json:"home_location,omitempty" jsonschema:"description=The location id or name the floating ip is routed to by default (e.g. fsn1). Either home_location or server is required"`
+	Server       int64             `json:"server,omitempty" jsonschema:"description=The id of the server to assign the floating ip to. Its location becomes the home location"`
+	Labels       map[string]string `json:"labels,omitempty" jsonschema:"description=User-defined labels for the floating ip"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// FloatingIPActionArgs represents the arguments required to run an action on a Floating IP.
+// It contains the Floating IP ID the action is performed on.
+type FloatingIPActionArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The floating ip id"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// FloatingIPUpdateArgs represents the arguments required to rename a Floating IP, change its description or replace its labels.
+type FloatingIPUpdateArgs struct {
+	ID          int64             `json:"id" jsonschema:"required,description=The floating ip id"`
+	Name        string            `json:"name,omitempty" jsonschema:"description=New name of the floating ip"`
+	Description *string           `json:"description,omitempty" jsonschema:"description=New description of the floating ip"`
+	Labels      map[string]string `json:"labels,omitempty" jsonschema:"description=New labels of the floating ip. Replaces all existing labels"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// FloatingIPAssignArgs represents the arguments required to assign a Floating IP to a Server.
+type FloatingIPAssignArgs struct {
+	ID     int64 `json:"id" jsonschema:"required,description=The floating ip id"`
+	Server int64 `json:"server" jsonschema:"required,description=The id of the server to assign the floating ip to. An assigned floating ip is moved to this server"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+type FloatingIPResponse struct {
+	ID           int64             `json:"id" jsonschema:"required,description=Unique identifier of the floating ip"`
+	Name         string            `json:"name" jsonschema:"required,description=The name of the floating ip"`
+	Description  string            `json:"description" jsonschema:"description=The description of the floating ip"`
+	Type         string            `json:"type" jsonschema:"required,description=The type of the floating ip either of ipv4 or ipv6"`
+	IP           string            `json:"ip" jsonschema:"required,description=The ip address; for ipv6 the first address of the network"`
+	Network      string            `json:"network,omitempty" jsonschema:"description=The ipv6 network in CIDR notation"`
+	Server       *ResourceRef      `json:"server" jsonschema:"description=The server the floating ip is assigned to; null if it is unassigned"`
+	HomeLocation *ResourceRef      `json:"home_location" jsonschema:"required,description=The location the floating ip is routed to by default"`
+	DNSPtr       map[string]string `json:"dns_ptr" jsonschema:"description=Reverse DNS entries per ip address"`
+	Blocked      bool              `json:"blocked" jsonschema:"required,description=Whether the floating ip is blocked"`
+	Protection   DeleteProtection  `json:"protection" jsonschema:"required,description=Protection settings of the floating ip"`
+	Labels       map[string]string `json:"labels" jsonschema:"description=User-defined labels for the floating ip"`
+	Created      time.Time         `json:"created" jsonschema:"required,description=Timestamp of when the floating ip was created"`
+}
+
+func toFloatingIPResponse(f *hcloud.FloatingIP) *FloatingIPResponse {
+	if f == nil {
+		return nil
+	}
+
+	var network string
+	if f.Type == hcloud.FloatingIPTypeIPv6 {
+		network = ipNetString(f.Network)
+	}
+
+	return &FloatingIPResponse{
+		ID:           f.ID,
+		Name:         f.Name,
+		Description:  f.Description,
+		Type:         string(f.Type),
+		IP:           ipString(f.IP),
+		Network:      network,
+		Server:       toServerRef(f.Server),
+		HomeLocation: toLocationRef(f.HomeLocation),
+		DNSPtr:       f.DNSPtr,
+		Blocked:      f.Blocked,
+		Protection:   DeleteProtection{Delete: f.Protection.Delete},
+		Labels:       f.Labels,
+		Created:      f.Created,
+	}
+}
+
+type FloatingIPCreateResponse struct {
+	FloatingIP *FloatingIPResponse `json:"floating_ip" jsonschema:"required,description=The created floating ip"`
+	Action     *ActionResponse     `json:"action" jsonschema:"description=The action assigning the floating ip; null if it was not assigned to a server"`
+}
+
+type FloatingIPActionResponse struct {
+	FloatingIP *FloatingIPResponse `json:"floating_ip" jsonschema:"description=The floating ip the action was performed on; null if it was deleted"`
+	Action     *ActionResponse     `json:"action" jsonschema:"description=The triggered action; null if the change did not need one"`
+}
+
+func toFloatingIPCreateOpts(ctx context.Context, args FloatingIPCreateArgs) (hcloud.FloatingIPCreateOpts, error) {
+	opts := hcloud.FloatingIPCreateOpts{
+		Type: hcloud.FloatingIPType(args.Type),
+	}
+	if opts.Type != hcloud.FloatingIPTypeIPv4 && opts.Type != hcloud.FloatingIPTypeIPv6 {
+		return opts, fmt.Errorf("invalid type %q, must be one of ipv4, ipv6", args.Type)
+	}
+	if args.Name != EmptyString {
+		opts.Name = hcloud.Ptr(args.Name)
+	}
+	if args.Description != EmptyString {
+		opts.Description = hcloud.Ptr(args.Description)
+	}
+	labels, err := scopeLabels(args.Labels)
+	if err != nil {
+		return opts, err
+	}
+	opts.Labels = labels
+
+	switch {
+	case args.HomeLocation != EmptyString && args.Server != 0:
+		return opts, fmt.Errorf("either home_location or server is allowed, not both")
+	case args.Server != 0:
+		server, err := getServer(ctx, args.Server)
+		if err != nil {
+			return opts, err
+		}
+		if err := checkScope("server", server.ID, server.Labels); err != nil {
+			return opts, err
+		}
+		opts.Server = server
+	case args.HomeLocation != EmptyString:
+		location, _, err := hcloudClient(ctx).Location.Get(ctx, args.HomeLocation)
+		if err != nil {
+			return opts, err
+		}
+		if location == nil {
+			return opts, fmt.Errorf("location %s not found", args.HomeLocation)
+		}
+		opts.HomeLocation = location
+	default:
+		return opts, fmt.Errorf("either home_location or server is required")
+	}
+	return opts, nil
+}
+
+// planFloatingIPCreate validates the options for creating a Floating IP and returns the planned change.
+func planFloatingIPCreate(ctx context.Context, opts hcloud.FloatingIPCreateOpts) (*PlannedChange, error) {
+	var name, description string
+	if opts.Name != nil {
+		name = *opts.Name
+		existing, _, err := hcloudClient(ctx).FloatingIP.GetByName(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("floating ip %s already exists", name)
+		}
+	}
+	if opts.Description != nil {
+		description = *opts.Description
+	}
+
+	location := opts.HomeLocation
+	if opts.Server != nil && opts.Server.Datacenter != nil {
+		location = opts.Server.Datacenter.Location
+	}
+	result := &FloatingIPCreateResponse{
+		FloatingIP: &FloatingIPResponse{
+			Name:         name,
+			Description:  description,
+			Type:         string(opts.Type),
+			Server:       toServerRef(opts.Server),
+			HomeLocation: toLocationRef(location),
+			Labels:       opts.Labels,
+			Created:      time.Now(),
+		},
+	}
+	if opts.Server != nil {
+		result.Action = plannedAction("assign_floating_ip", ActionResource{ID: opts.Server.ID, Type: string(hcloud.ActionResourceTypeServer)})
+	}
+
+	return newPlannedChange("create_floating_ip", result), nil
+}
+
+// getFloatingIP retrieves a Floating IP by its ID and fails if the Floating IP does not exist.
+func getFloatingIP(ctx context.Context, id int64) (*hcloud.FloatingIP, error) {
+	floatingIP, _, err := hcloudClient(ctx).FloatingIP.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if floatingIP == nil {
+		return nil, fmt.Errorf("floating ip %d not found", id)
+	}
+	return floatingIP, nil
+}
+
+// floatingIPTarget runs actions on Floating IPs with runAction.
+var floatingIPTarget = actionTarget[hcloud.FloatingIP, FloatingIPResponse]{
+	name:       "floating ip",
+	actionType: string(hcloud.ActionResourceTypeFloatingIP),
+	get:        getFloatingIP,
+	labels:     func(floatingIP *hcloud.FloatingIP) map[string]string { return floatingIP.Labels },
+	convert:    toFloatingIPResponse,
+	respond: func(floatingIP *FloatingIPResponse, actions []*ActionResponse) any {
+		return &FloatingIPActionResponse{FloatingIP: floatingIP, Action: firstAction(actions)}
+	},
 }
 
 // FloatingIPTools
 var floatingIPTools = []Tool{
 	{
 		Name:        "get_all_floating_ips",
-		Description: "Returns all FloatingIPs objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.FloatingIP, error) {
-				result, err := client.FloatingIP.All(context.Background())
-				return result, err
+		Description: "Returns a page of FloatingIPs, optionally filtered by name and label selector.",
+		Handler: func(ctx context.Context, args FloatingIPListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*FloatingIPResponse], error) {
+				listOpts, err := args.listOpts()
+				if err != nil {
+					return nil, err
+				}
+				opts := hcloud.FloatingIPListOpts{
+					ListOpts: listOpts,
+					Name:     args.Name,
+				}
+				result, resp, err := hcloudClient(ctx).FloatingIP.List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return newListResponse(toResponseList(result, toFloatingIPResponse), resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -29,12 +258,171 @@ var floatingIPTools = []Tool{
 	{
 		Name:        "get_a_floating_ip_by_id_or_name",
 		Description: "Retrieves a FloatingIP by its ID or Name, Get retrieves a FloatingIP by its ID if the input can be parsed as an integer, otherwise it retrieves a FloatingIP by its name. If the FloatingIP does not exist, nil is returned.",
-		Handler: func(args FloatingIPReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.FloatingIP, error) {
-				result, _, err := client.FloatingIP.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args FloatingIPReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*FloatingIPResponse, error) {
+				result, _, err := hcloudClient(ctx).FloatingIP.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return toFloatingIPResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
 	},
+	{
+		Name:        "create_a_floating_ip",
+		Description: "Creates a new Floating IP in a home location or assigned to a Server. The address must be configured on the server to receive traffic.",
+		Handler: func(ctx context.Context, args FloatingIPCreateArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				opts, err := toFloatingIPCreateOpts(ctx, args)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					return planFloatingIPCreate(ctx, opts)
+				}
+				result, _, err := hcloudClient(ctx).FloatingIP.Create(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				recordActions(ctx, result.Action)
+				if result.Action != nil && args.Wait {
+					result.Action, err = waitForAction(ctx, result.Action)
+					if err != nil {
+						return nil, err
+					}
+					result.FloatingIP, _, err = hcloudClient(ctx).FloatingIP.GetByID(ctx, result.FloatingIP.ID)
+					if err != nil {
+						return nil, err
+					}
+				}
+				return &FloatingIPCreateResponse{
+					FloatingIP: toFloatingIPResponse(result.FloatingIP),
+					Action:     toActionResponse(result.Action),
+				}, nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "update_a_floating_ip",
+		Description: "Renames a Floating IP, changes its description or replaces its labels.",
+		Handler: func(ctx context.Context, args FloatingIPUpdateArgs) (*mcpgolang.ToolResponse, error) {
+			var opts hcloud.FloatingIPUpdateOpts
+			return runAction(ctx, floatingIPTarget, args.ID, args.WriteArgs, "update_floating_ip", true,
+				func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*FloatingIPResponse, []string, error) {
+					if args.Name == EmptyString && args.Description == nil && args.Labels == nil {
+						return nil, nil, fmt.Errorf("either name, description or labels is required")
+					}
+					expected := toFloatingIPResponse(floatingIP)
+					if args.Name != EmptyString {
+						opts.Name = args.Name
+						expected.Name = args.Name
+					}
+					if args.Description != nil {
+						opts.Description = *args.Description
+						expected.Description = *args.Description
+					}
+					if args.Labels != nil {
+						labels, err := scopeLabels(args.Labels)
+						if err != nil {
+							return nil, nil, err
+						}
+						opts.Labels = labels
+						expected.Labels = labels
+					}
+					return expected, nil, nil
+				},
+				singleAction(func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*hcloud.Action, error) {
+					_, _, err := hcloudClient(ctx).FloatingIP.Update(ctx, floatingIP, opts)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "assign_floating_ip",
+		Description: "Assigns a Floating IP to a Server. A floating ip assigned to another server is moved, which fails over its traffic to the new server.",
+		Handler: func(ctx context.Context, args FloatingIPAssignArgs) (*mcpgolang.ToolResponse, error) {
+			var server *hcloud.Server
+			return runAction(ctx, floatingIPTarget, args.ID, args.WriteArgs, "assign_floating_ip", false,
+				func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*FloatingIPResponse, []string, error) {
+					var err error
+					server, err = getServer(ctx, args.Server)
+					if err != nil {
+						return nil, nil, err
+					}
+					if err := checkScope("server", server.ID, server.Labels); err != nil {
+						return nil, nil, err
+					}
+					if floatingIP.Server != nil && floatingIP.Server.ID == server.ID {
+						return nil, nil, fmt.Errorf("floating ip %d is already assigned to server %d", floatingIP.ID, server.ID)
+					}
+					var warnings []string
+					if floatingIP.Server != nil {
+						warnings = append(warnings, fmt.Sprintf("floating ip %d will be moved from server %d to server %d", floatingIP.ID, floatingIP.Server.ID, server.ID))
+					}
+					if server.Datacenter != nil && server.Datacenter.Location != nil && floatingIP.HomeLocation != nil &&
+						server.Datacenter.Location.ID != floatingIP.HomeLocation.ID {
+						warnings = append(warnings, fmt.Sprintf("server %d is not in the home location %s of floating ip %d, traffic is routed through %s", server.ID, floatingIP.HomeLocation.Name, floatingIP.ID, floatingIP.HomeLocation.Name))
+					}
+					if server.Status != hcloud.ServerStatusRunning {
+						warnings = append(warnings, fmt.Sprintf("server %d is %s and will not receive traffic until it is running", server.ID, server.Status))
+					}
+					expected := toFloatingIPResponse(floatingIP)
+					expected.Server = toServerRef(server)
+					return expected, warnings, nil
+				},
+				singleAction(func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).FloatingIP.Assign(ctx, floatingIP, server)
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "unassign_floating_ip",
+		Description: "Unassigns a Floating IP from its Server. The floating ip stops receiving traffic.",
+		Handler: func(ctx context.Context, args FloatingIPActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, floatingIPTarget, args.ID, args.WriteArgs, "unassign_floating_ip", false,
+				func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*FloatingIPResponse, []string, error) {
+					if floatingIP.Server == nil {
+						return nil, nil, fmt.Errorf("floating ip %d is not assigned to a server", floatingIP.ID)
+					}
+					expected := toFloatingIPResponse(floatingIP)
+					expected.Server = nil
+					return expected, nil, nil
+				},
+				singleAction(func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).FloatingIP.Unassign(ctx, floatingIP)
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_a_floating_ip",
+		Description: "Deletes a Floating IP. An assigned floating ip is unassigned first. The address is released, it cannot be undone.",
+		Handler: func(ctx context.Context, args FloatingIPActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, floatingIPTarget, args.ID, args.WriteArgs, "delete_floating_ip", true,
+				func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*FloatingIPResponse, []string, error) {
+					if floatingIP.Protection.Delete {
+						return nil, nil, fmt.Errorf("floating ip %d is protected against deletion", floatingIP.ID)
+					}
+					var warnings []string
+					if floatingIP.Server != nil {
+						warnings = append(warnings, fmt.Sprintf("floating ip %d will be unassigned from server %d", floatingIP.ID, floatingIP.Server.ID))
+					}
+					return nil, warnings, nil
+				},
+				singleAction(func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*hcloud.Action, error) {
+					_, err := hcloudClient(ctx).FloatingIP.Delete(ctx, floatingIP)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
 }
@@ -1,7 +1,10 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	mcpgolang "github.com/metoro-io/mcp-golang"
@@ -11,17 +14,254 @@ import (
 // It contains the Image ID that is needed to perform the lookup.
 type ImageReadByIDArgs struct {
 	ID int64 `json:"id" jsonschema:"required,description=The image id to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// ImageReadByNameArgs represents the arguments required to read an Image by Name.
+// Images of different architectures share their names, so the architecture is part of the lookup.
+type ImageReadByNameArgs struct {
+	Name         string `json:"name" jsonschema:"required,description=The image name to be searched (e.g. ubuntu-24.04)"`
+	Architecture string `json:"architecture,omitempty" jsonschema:"description=The architecture of the image (x86/arm). Defaults to x86"`
+	ProjectArgs
+	OutputArgs
+}
+
+// ImageListArgs represents the arguments for listing Images.
+// Images can be filtered by name, type, status, architecture and the server they are bound to on top of the label selector.
+type ImageListArgs struct {
+	Name              string   `json:"name,omitempty" jsonschema:"description=Only return the image with this name"`
+	Type              []string `json:"type,omitempty" jsonschema:"description=Only return images of these types (system/app/snapshot/backup)"`
+	Status            []string `json:"status,omitempty" jsonschema:"description=Only return images with one of these statuses (available/creating)"`
+	Architecture      []string `json:"architecture,omitempty" jsonschema:"description=Only return images for these architectures (x86/arm)"`
+	BoundTo           int64    `json:"bound_to,omitempty" jsonschema:"description=Only return backups and snapshots created from the server with this id"`
+	IncludeDeprecated bool     `json:"include_deprecated,omitempty" jsonschema:"description=Also return deprecated images"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// ServerSnapshotArgs represents the arguments required to create a snapshot or backup of a Server.
+type ServerSnapshotArgs struct {
+	Server      int64             `json:"server" jsonschema:"required,description=The id of the server to create the image from"`
+	Type        string            `json:"type,omitempty" jsonschema:"description=The image type (snapshot/backup). Defaults to snapshot. Backups need enabled backups and count towards the backups of the server"`
+	Description string            `json:"description,omitempty" jsonschema:"description=The image description (e.g. before upgrading postgres)"`
+	Labels      map[string]string `json:"labels,omitempty" jsonschema:"description=User-defined labels for the image"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// ImageActionArgs represents the arguments required to run an action on an Image.
+// It contains the Image ID the action is performed on.
+type ImageActionArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The image id"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// ImageUpdateArgs represents the arguments required to change the description of an Image, replace its labels
+// or convert a backup into a snapshot.
+type ImageUpdateArgs struct {
+	ID          int64             `json:"id" jsonschema:"required,description=The image id"`
+	Description *string           `json:"description,omitempty" jsonschema:"description=New description of the image"`
+	Type        string            `json:"type,omitempty" jsonschema:"description=Set to snapshot to convert a backup into a snapshot"`
+	Labels      map[string]string `json:"labels,omitempty" jsonschema:"description=New labels of the image. Replaces all existing labels"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// ImageProtectionArgs represents the arguments required to change the protection of an Image.
+type ImageProtectionArgs struct {
+	ID     int64 `json:"id" jsonschema:"required,description=The image id"`
+	Delete bool  `json:"delete" jsonschema:"required,description=Whether the image is protected from deletion"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+type ImageResponse struct {
+	ID           int64             `json:"id" jsonschema:"required,description=Unique identifier of the image"`
+	Name         string            `json:"name" jsonschema:"description=The name of the image; only set for system and app images"`
+	Description  string            `json:"description" jsonschema:"description=The description of the image"`
+	Type         string            `json:"type" jsonschema:"required,description=The type of the image (system/app/snapshot/backup)"`
+	Status       string            `json:"status" jsonschema:"required,description=Status of the image either of available or creating"`
+	Architecture string            `json:"architecture" jsonschema:"required,description=CPU architecture the image is compatible with"`
+	OSFlavor     string            `json:"os_flavor" jsonschema:"description=Flavor of the operating system (e.g. ubuntu)"`
+	OSVersion    string            `json:"os_version" jsonschema:"description=Version of the operating system"`
+	ImageSize    float32           `json:"image_size" jsonschema:"description=Size of the image in GB; only set for snapshots and backups"`
+	DiskSize     float32           `json:"disk_size" jsonschema:"required,description=Size of the disk contained in the image in GB"`
+	RapidDeploy  bool              `json:"rapid_deploy" jsonschema:"description=Whether the image can be used for rapid deployments"`
+	CreatedFrom  *ResourceRef      `json:"created_from" jsonschema:"description=The server the image was created from"`
+	BoundTo      *ResourceRef      `json:"bound_to" jsonschema:"description=The server a backup is bound to"`
+	Protection   DeleteProtection  `json:"protection" jsonschema:"required,description=Protection settings of the image"`
+	Labels       map[string]string `json:"labels" jsonschema:"description=User-defined labels for the image"`
+	Created      time.Time         `json:"created" jsonschema:"required,description=Timestamp of when the image was created"`
+	Deprecated   *time.Time        `json:"deprecated,omitempty" jsonschema:"description=Timestamp of when the image was deprecated"`
+}
+
+func toImageResponse(i *hcloud.Image) *ImageResponse {
+	if i == nil {
+		return nil
+	}
+
+	return &ImageResponse{
+		ID:           i.ID,
+		Name:         i.Name,
+		Description:  i.Description,
+		Type:         string(i.Type),
+		Status:       string(i.Status),
+		Architecture: string(i.Architecture),
+		OSFlavor:     i.OSFlavor,
+		OSVersion:    i.OSVersion,
+		ImageSize:    i.ImageSize,
+		DiskSize:     i.DiskSize,
+		RapidDeploy:  i.RapidDeploy,
+		CreatedFrom:  toServerRef(i.CreatedFrom),
+		BoundTo:      toServerRef(i.BoundTo),
+		Protection:   DeleteProtection{Delete: i.Protection.Delete},
+		Labels:       i.Labels,
+		Created:      i.Created,
+		Deprecated:   timePtr(i.Deprecated),
+	}
+}
+
+type ImageCreateResponse struct {
+	Image  *ImageResponse  `json:"image" jsonschema:"required,description=The created image"`
+	Action *ActionResponse `json:"action" jsonschema:"required,description=The action creating the image"`
+}
+
+type ImageActionResponse struct {
+	Image  *ImageResponse  `json:"image" jsonschema:"description=The image the action was performed on; null if it was deleted"`
+	Action *ActionResponse `json:"action" jsonschema:"description=The triggered action; null if the change did not need one"`
+}
+
+// getImageForArchitecture retrieves an Image by its ID or by its name for the given architecture and fails if the Image does not exist.
+func getImageForArchitecture(ctx context.Context, idOrName string, architecture hcloud.Architecture) (*hcloud.Image, error) {
+	image, _, err := hcloudClient(ctx).Image.GetForArchitecture(ctx, idOrName, architecture)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, fmt.Errorf("image %s not found for architecture %s", idOrName, architecture)
+	}
+	return image, nil
+}
+
+// getImage retrieves an Image by its ID and fails if the Image does not exist.
+func getImage(ctx context.Context, id int64) (*hcloud.Image, error) {
+	image, _, err := hcloudClient(ctx).Image.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, fmt.Errorf("image %d not found", id)
+	}
+	return image, nil
+}
+
+// getChangeableImage retrieves an Image by its ID and fails unless it is a snapshot or backup,
+// system and app images cannot be changed.
+func getChangeableImage(ctx context.Context, id int64) (*hcloud.Image, error) {
+	image, err := getImage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if image.Type != hcloud.ImageTypeSnapshot && image.Type != hcloud.ImageTypeBackup {
+		return nil, fmt.Errorf("image %d is a %s image, only snapshots and backups can be changed", image.ID, image.Type)
+	}
+	return image, nil
+}
+
+// toServerCreateImageOpts validates the arguments for creating an Image of a Server.
+// It returns the Server, the options and the warnings worth knowing before creating the Image.
+func toServerCreateImageOpts(ctx context.Context, args ServerSnapshotArgs) (*hcloud.Server, *hcloud.ServerCreateImageOpts, []string, error) {
+	server, err := getServer(ctx, args.Server)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if err := checkScope("server", server.ID, server.Labels); err != nil {
+		return nil, nil, nil, err
+	}
+	labels, err := scopeLabels(args.Labels)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	opts := &hcloud.ServerCreateImageOpts{
+		Type:   hcloud.ImageType(cmp.Or(args.Type, string(hcloud.ImageTypeSnapshot))),
+		Labels: labels,
+	}
+	if args.Description != EmptyString {
+		opts.Description = hcloud.Ptr(args.Description)
+	}
+	switch opts.Type {
+	case hcloud.ImageTypeSnapshot:
+	case hcloud.ImageTypeBackup:
+		if server.BackupWindow == EmptyString {
+			return nil, nil, nil, fmt.Errorf("backups of server %d are not enabled, enable them with enable_server_backup or create a snapshot", server.ID)
+		}
+	default:
+		return nil, nil, nil, fmt.Errorf("invalid image type %q, must be one of snapshot, backup", args.Type)
+	}
+
+	var warnings []string
+	if server.Status == hcloud.ServerStatusRunning {
+		warnings = append(warnings, fmt.Sprintf("server %d is running, shut it down first for a consistent image", server.ID))
+	}
+	if opts.Type == hcloud.ImageTypeBackup {
+		warnings = append(warnings, fmt.Sprintf("the backup replaces the oldest backup of server %d once 7 backups exist", server.ID))
+	}
+	return server, opts, warnings, nil
+}
+
+// imageTarget runs actions on Images with runAction.
+var imageTarget = actionTarget[hcloud.Image, ImageResponse]{
+	name:       "image",
+	actionType: string(hcloud.ActionResourceTypeImage),
+	get:        getChangeableImage,
+	labels:     func(image *hcloud.Image) map[string]string { return image.Labels },
+	convert:    toImageResponse,
+	respond: func(image *ImageResponse, actions []*ActionResponse) any {
+		return &ImageActionResponse{Image: image, Action: firstAction(actions)}
+	},
 }
 
 // ImageTools
 var imageTools = []Tool{
 	{
 		Name:        "get_all_images",
-		Description: "Returns all Images objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.Image, error) {
-				result, err := client.Image.All(context.Background())
-				return result, err
+		Description: "Returns a page of Images, optionally filtered by name, type, status, architecture, the server they are bound to and label selector.",
+		Handler: func(ctx context.Context, args ImageListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*ImageResponse], error) {
+				listOpts, err := args.listOpts()
+				if err != nil {
+					return nil, err
+				}
+				opts := hcloud.ImageListOpts{
+					ListOpts:          listOpts,
+					Name:              args.Name,
+					IncludeDeprecated: args.IncludeDeprecated,
+				}
+				for _, t := range args.Type {
+					opts.Type = append(opts.Type, hcloud.ImageType(t))
+				}
+				for _, status := range args.Status {
+					opts.Status = append(opts.Status, hcloud.ImageStatus(status))
+				}
+				for _, architecture := range args.Architecture {
+					opts.Architecture = append(opts.Architecture, hcloud.Architecture(architecture))
+				}
+				if args.BoundTo != 0 {
+					opts.BoundTo = &hcloud.Server{ID: args.BoundTo}
+				}
+				result, resp, err := hcloudClient(ctx).Image.List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return newListResponse(toResponseList(result, toImageResponse), resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -29,12 +269,178 @@ var imageTools = []Tool{
 	{
 		Name:        "get_a_image_by_id",
 		Description: "Retrieves a Image by its ID. If the Image does not exist, nil is returned.",
-		Handler: func(args ImageReadByIDArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.Image, error) {
-				result, _, err := client.Image.GetByID(context.Background(), args.ID)
-				return result, err
+		Handler: func(ctx context.Context, args ImageReadByIDArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ImageResponse, error) {
+				result, _, err := hcloudClient(ctx).Image.GetByID(ctx, args.ID)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return toImageResponse(result), nil
+			})
+		},
+		Restriction: RestrictionReadOnly,
+	},
+	{
+		Name:        "get_a_image_by_name",
+		Description: "Retrieves a system or app Image by its Name and architecture. Snapshots and backups have no name, look them up with get_all_images instead. If the Image does not exist, nil is returned.",
+		Handler: func(ctx context.Context, args ImageReadByNameArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ImageResponse, error) {
+				architecture := hcloud.Architecture(cmp.Or(args.Architecture, string(hcloud.ArchitectureX86)))
+				if architecture != hcloud.ArchitectureX86 && architecture != hcloud.ArchitectureARM {
+					return nil, fmt.Errorf("invalid architecture %q, must be one of x86, arm", args.Architecture)
+				}
+				result, _, err := hcloudClient(ctx).Image.GetByNameAndArchitecture(ctx, args.Name, architecture)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return toImageResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
 	},
+	{
+		Name:        "create_a_server_snapshot",
+		Description: "Creates a snapshot or backup Image of the disk of a Server, e.g. before a risky change. Snapshots of running servers may be inconsistent.",
+		Handler: func(ctx context.Context, args ServerSnapshotArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				server, opts, warnings, err := toServerCreateImageOpts(ctx, args)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					var description string
+					if opts.Description != nil {
+						description = *opts.Description
+					}
+					return newPlannedChange("create_image", &ImageCreateResponse{
+						Image: &ImageResponse{
+							Description:  description,
+							Type:         string(opts.Type),
+							Status:       string(hcloud.ImageStatusCreating),
+							Architecture: string(server.ServerType.Architecture),
+							DiskSize:     float32(server.PrimaryDiskSize),
+							CreatedFrom:  toServerRef(server),
+							Labels:       opts.Labels,
+							Created:      time.Now(),
+						},
+						Action: plannedAction("create_image", ActionResource{ID: server.ID, Type: string(hcloud.ActionResourceTypeServer)}),
+					}, warnings...), nil
+				}
+				result, _, err := hcloudClient(ctx).Server.CreateImage(ctx, server, opts)
+				if err != nil {
+					return nil, err
+				}
+				recordActions(ctx, result.Action)
+				if args.Wait {
+					result.Action, err = waitForAction(ctx, result.Action)
+					if err != nil {
+						return nil, err
+					}
+					result.Image, _, err = hcloudClient(ctx).Image.GetByID(ctx, result.Image.ID)
+					if err != nil {
+						return nil, err
+					}
+				}
+				return &ImageCreateResponse{
+					Image:  toImageResponse(result.Image),
+					Action: toActionResponse(result.Action),
+				}, nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "update_a_image",
+		Description: "Changes the description of a snapshot or backup Image, replaces its labels or converts a backup into a snapshot.",
+		Handler: func(ctx context.Context, args ImageUpdateArgs) (*mcpgolang.ToolResponse, error) {
+			var opts hcloud.ImageUpdateOpts
+			return runAction(ctx, imageTarget, args.ID, args.WriteArgs, "update_image", true,
+				func(ctx context.Context, image *hcloud.Image) (*ImageResponse, []string, error) {
+					if args.Description == nil && args.Type == EmptyString && args.Labels == nil {
+						return nil, nil, fmt.Errorf("either description, type or labels is required")
+					}
+					expected := toImageResponse(image)
+					var warnings []string
+					if args.Description != nil {
+						opts.Description = args.Description
+						expected.Description = *args.Description
+					}
+					if args.Type != EmptyString {
+						if hcloud.ImageType(args.Type) != hcloud.ImageTypeSnapshot {
+							return nil, nil, fmt.Errorf("invalid image type %q, images can only be converted to snapshot", args.Type)
+						}
+						if image.Type == hcloud.ImageTypeSnapshot {
+							return nil, nil, fmt.Errorf("image %d is already a snapshot", image.ID)
+						}
+						if image.BoundTo != nil {
+							warnings = append(warnings, fmt.Sprintf("the snapshot is no longer bound to server %d and is kept when the server is deleted", image.BoundTo.ID))
+						}
+						opts.Type = hcloud.ImageTypeSnapshot
+						expected.Type = string(hcloud.ImageTypeSnapshot)
+						expected.BoundTo = nil
+					}
+					if args.Labels != nil {
+						labels, err := scopeLabels(args.Labels)
+						if err != nil {
+							return nil, nil, err
+						}
+						opts.Labels = labels
+						expected.Labels = labels
+					}
+					return expected, warnings, nil
+				},
+				singleAction(func(ctx context.Context, image *hcloud.Image) (*hcloud.Action, error) {
+					_, _, err := hcloudClient(ctx).Image.Update(ctx, image, opts)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "change_image_protection",
+		Description: "Enables or disables the delete protection of a snapshot or backup Image.",
+		Handler: func(ctx context.Context, args ImageProtectionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, imageTarget, args.ID, args.WriteArgs, "change_protection", false,
+				func(ctx context.Context, image *hcloud.Image) (*ImageResponse, []string, error) {
+					var warnings []string
+					if image.Protection.Delete == args.Delete {
+						warnings = append(warnings, fmt.Sprintf("delete protection of image %d is already %t", image.ID, args.Delete))
+					}
+					expected := toImageResponse(image)
+					expected.Protection.Delete = args.Delete
+					return expected, warnings, nil
+				},
+				singleAction(func(ctx context.Context, image *hcloud.Image) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Image.ChangeProtection(ctx, image, hcloud.ImageChangeProtectionOpts{
+						Delete: hcloud.Ptr(args.Delete),
+					})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_a_image",
+		Description: "Deletes a snapshot or backup Image. The image must not be protected. It cannot be undone.",
+		Handler: func(ctx context.Context, args ImageActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, imageTarget, args.ID, args.WriteArgs, "delete_image", true,
+				func(ctx context.Context, image *hcloud.Image) (*ImageResponse, []string, error) {
+					if image.Protection.Delete {
+						return nil, nil, fmt.Errorf("image %d is protected against deletion", image.ID)
+					}
+					return nil, nil, nil
+				},
+				singleAction(func(ctx context.Context, image *hcloud.Image) (*hcloud.Action, error) {
+					_, err := hcloudClient(ctx).Image.Delete(ctx, image)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
 }
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net"
+	"regexp"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	mcpgolang "github.com/metoro-io/mcp-golang"
+)
+
+// Kinds of IP resources the tools in this file change.
+const (
+	IPKindFloatingIP = "floating_ip"
+	IPKindPrimaryIP  = "primary_ip"
+)
+
+// IPArgs identifies a Floating IP or a Primary IP.
+type IPArgs struct {
+	Kind string `json:"kind" jsonschema:"required,description=The kind of ip (floating_ip/primary_ip)"`
+	ID   int64  `json:"id" jsonschema:"required,description=The floating ip or primary ip id"`
+}
+
+// IPProtectionArgs represents the arguments required to change the protection of a Floating IP or a Primary IP.
+type IPProtectionArgs struct {
+	IPArgs
+	Delete bool `json:"delete" jsonschema:"required,description=Whether the ip is protected from deletion"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// DNSPtrArgs represents the reverse DNS entry of an IP address.
+type DNSPtrArgs struct {
+	IP     string `json:"ip,omitempty" jsonschema:"description=The ip address to set the reverse DNS entry for. Defaults to the ipv4 address; required for ipv6 and must be inside its network"`
+	DNSPtr string `json:"dns_ptr,omitempty" jsonschema:"description=The hostname the ip address resolves to. Resets the entry to the default if empty"`
+}
+
+// IPDNSPtrArgs represents the arguments required to change the reverse DNS entry of a Floating IP or a Primary IP.
+type IPDNSPtrArgs struct {
+	IPArgs
+	DNSPtrArgs
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+var hostnamePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.?$`)
+
+// parseDNSPtr validates a reverse DNS entry of an IP resource with the given address or IPv6 network.
+// It returns the IP address the entry is set for.
+func parseDNSPtr(address net.IP, network *net.IPNet, args DNSPtrArgs) (string, error) {
+	if args.DNSPtr != EmptyString && (len(args.DNSPtr) > 253 || !hostnamePattern.MatchString(args.DNSPtr)) {
+		return EmptyString, fmt.Errorf("invalid dns_ptr %q, must be a hostname (e.g. www.example.com)", args.DNSPtr)
+	}
+	if args.IP == EmptyString {
+		if network != nil {
+			return EmptyString, fmt.Errorf("missing ip, give an address of the ipv6 network %s", network)
+		}
+		return address.String(), nil
+	}
+	ip, err := parseIP("ip", args.IP)
+	if err != nil {
+		return EmptyString, err
+	}
+	if network != nil && !network.Contains(ip) {
+		return EmptyString, fmt.Errorf("ip %s is not inside the ipv6 network %s", ip, network)
+	}
+	if network == nil && !ip.Equal(address) {
+		return EmptyString, fmt.Errorf("ip %s is not the address %s", ip, address)
+	}
+	return ip.String(), nil
+}
+
+// withDNSPtr returns a copy of the reverse DNS entries with the entry for the IP address changed.
+func withDNSPtr(entries map[string]string, ip, dnsPtr string) map[string]string {
+	result := maps.Clone(entries)
+	if result == nil {
+		result = make(map[string]string)
+	}
+	if dnsPtr == EmptyString {
+		delete(result, ip)
+	} else {
+		result[ip] = dnsPtr
+	}
+	return result
+}
+
+// changeDNSPtr changes the reverse DNS entry of the IP address, or resets it to the default if dnsPtr is empty.
+func changeDNSPtr(ctx context.Context, resource hcloud.RDNSSupporter, ip, dnsPtr string) (*hcloud.Action, error) {
+	var ptr *string
+	if dnsPtr != EmptyString {
+		ptr = hcloud.Ptr(dnsPtr)
+	}
+	result, _, err := hcloudClient(ctx).RDNS.ChangeDNSPtr(ctx, resource, net.ParseIP(ip), ptr)
+	return result, err
+}
+
+// invalidIPKind fails a tool called with an unknown kind of IP.
+func invalidIPKind(ctx context.Context, kind string) (*mcpgolang.ToolResponse, error) {
+	return handleResponse(ctx, func() (any, error) {
+		return nil, fmt.Errorf("invalid kind %q, must be one of %s, %s", kind, IPKindFloatingIP, IPKindPrimaryIP)
+	})
+}
+
+// IPTools change Floating IPs and Primary IPs alike.
+var ipTools = []Tool{
+	{
+		Name:        "change_dns_ptr",
+		Description: "Changes or resets the reverse DNS entry of a Floating IP or Primary IP address.",
+		Handler: func(ctx context.Context, args IPDNSPtrArgs) (*mcpgolang.ToolResponse, error) {
+			var ip string
+			switch args.Kind {
+			case IPKindFloatingIP:
+				return runAction(ctx, floatingIPTarget, args.ID, args.WriteArgs, "change_dns_ptr", false,
+					func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*FloatingIPResponse, []string, error) {
+						var network *net.IPNet
+						if floatingIP.Type == hcloud.FloatingIPTypeIPv6 {
+							network = floatingIP.Network
+						}
+						var err error
+						ip, err = parseDNSPtr(floatingIP.IP, network, args.DNSPtrArgs)
+						if err != nil {
+							return nil, nil, err
+						}
+						expected := toFloatingIPResponse(floatingIP)
+						expected.DNSPtr = withDNSPtr(floatingIP.DNSPtr, ip, args.DNSPtr)
+						return expected, nil, nil
+					},
+					singleAction(func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*hcloud.Action, error) {
+						return changeDNSPtr(ctx, floatingIP, ip, args.DNSPtr)
+					}))
+			case IPKindPrimaryIP:
+				return runAction(ctx, primaryIPTarget, args.ID, args.WriteArgs, "change_dns_ptr", false,
+					func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*PrimaryIPResponse, []string, error) {
+						var network *net.IPNet
+						if primaryIP.Type == hcloud.PrimaryIPTypeIPv6 {
+							network = primaryIP.Network
+						}
+						var err error
+						ip, err = parseDNSPtr(primaryIP.IP, network, args.DNSPtrArgs)
+						if err != nil {
+							return nil, nil, err
+						}
+						expected := toPrimaryIPResponse(primaryIP)
+						expected.DNSPtr = withDNSPtr(primaryIP.DNSPtr, ip, args.DNSPtr)
+						return expected, nil, nil
+					},
+					singleAction(func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*hcloud.Action, error) {
+						return changeDNSPtr(ctx, primaryIP, ip, args.DNSPtr)
+					}))
+			}
+			return invalidIPKind(ctx, args.Kind)
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "change_ip_protection",
+		Description: "Enables or disables the delete protection of a Floating IP or Primary IP.",
+		Handler: func(ctx context.Context, args IPProtectionArgs) (*mcpgolang.ToolResponse, error) {
+			switch args.Kind {
+			case IPKindFloatingIP:
+				return runAction(ctx, floatingIPTarget, args.ID, args.WriteArgs, "change_protection", false,
+					func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*FloatingIPResponse, []string, error) {
+						var warnings []string
+						if floatingIP.Protection.Delete == args.Delete {
+							warnings = append(warnings, fmt.Sprintf("delete protection of floating ip %d is already %t", floatingIP.ID, args.Delete))
+						}
+						expected := toFloatingIPResponse(floatingIP)
+						expected.Protection.Delete = args.Delete
+						return expected, warnings, nil
+					},
+					singleAction(func(ctx context.Context, floatingIP *hcloud.FloatingIP) (*hcloud.Action, error) {
+						result, _, err := hcloudClient(ctx).FloatingIP.ChangeProtection(ctx, floatingIP, hcloud.FloatingIPChangeProtectionOpts{
+							Delete: hcloud.Ptr(args.Delete),
+						})
+						return result, err
+					}))
+			case IPKindPrimaryIP:
+				return runAction(ctx, primaryIPTarget, args.ID, args.WriteArgs, "change_protection", false,
+					func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*PrimaryIPResponse, []string, error) {
+						var warnings []string
+						if primaryIP.Protection.Delete == args.Delete {
+							warnings = append(warnings, fmt.Sprintf("delete protection of primary ip %d is already %t", primaryIP.ID, args.Delete))
+						}
+						expected := toPrimaryIPResponse(primaryIP)
+						expected.Protection.Delete = args.Delete
+						return expected, warnings, nil
+					},
+					singleAction(func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*hcloud.Action, error) {
+						result, _, err := hcloudClient(ctx).PrimaryIP.ChangeProtection(ctx, hcloud.PrimaryIPChangeProtectionOpts{
+							ID:     primaryIP.ID,
+							Delete: args.Delete,
+						})
+						return result, err
+					}))
+			}
+			return invalidIPKind(ctx, args.Kind)
+		},
+		Restriction: RestrictionReadWrite,
+	},
+}
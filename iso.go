@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	mcpgolang "github.com/metoro-io/mcp-golang"
@@ -11,6 +12,57 @@ import (
 // It contains the ISO ID or Name that is needed to perform the lookup.
 type ISOReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The ISO id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+type ISOResponse struct {
+	ID           int64                `json:"id" jsonschema:"required,description=Unique identifier of the ISO"`
+	Name         string               `json:"name" jsonschema:"required,description=The name of the ISO"`
+	Description  string               `json:"description" jsonschema:"description=The description of the ISO"`
+	Type         string               `json:"type" jsonschema:"required,description=The type of the ISO either of public or private"`
+	Architecture string               `json:"architecture,omitempty" jsonschema:"description=CPU architecture the ISO is compatible with; empty if unknown"`
+	Deprecation  *DeprecationResponse `json:"deprecation,omitempty" jsonschema:"description=Deprecation of the ISO if it is deprecated"`
+}
+
+func toISOResponse(i *hcloud.ISO) *ISOResponse {
+	if i == nil {
+		return nil
+	}
+
+	var architecture string
+	if i.Architecture != nil {
+		architecture = string(*i.Architecture)
+	}
+
+	return &ISOResponse{
+		ID:           i.ID,
+		Name:         i.Name,
+		Description:  i.Description,
+		Type:         string(i.Type),
+		Architecture: architecture,
+		Deprecation:  toDeprecationResponse(i.DeprecatableResource.Deprecation),
+	}
+}
+
+// toISORef collapses an ISO referenced by another resource to its ID and name.
+func toISORef(i *hcloud.ISO) *ResourceRef {
+	if i == nil {
+		return nil
+	}
+	return toResourceRef(i.ID, i.Name)
+}
+
+// getISO retrieves an ISO by its ID or name and fails if the ISO does not exist.
+func getISO(ctx context.Context, idOrName string) (*hcloud.ISO, error) {
+	iso, _, err := hcloudClient(ctx).ISO.Get(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if iso == nil {
+		return nil, fmt.Errorf("iso %s not found", idOrName)
+	}
+	return iso, nil
 }
 
 // ISOTools
@@ -18,10 +70,13 @@ var isoTools = []Tool{
 	{
 		Name:        "get_all_isos",
 		Description: "Returns all ISOs objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.ISO, error) {
-				result, err := client.ISO.All(context.Background())
-				return result, err
+		Handler: func(ctx context.Context, _ NoArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() ([]*ISOResponse, error) {
+				result, err := hcloudClient(ctx).ISO.All(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return toResponseList(result, toISOResponse), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -29,10 +84,13 @@ var isoTools = []Tool{
 	{
 		Name:        "get_a_iso_by_id_or_name",
 		Description: "Retrieves a ISO by its ID or Name.",
-		Handler: func(args ISOReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.ISO, error) {
-				result, _, err := client.ISO.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args ISOReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ISOResponse, error) {
+				result, _, err := hcloudClient(ctx).ISO.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				return toISOResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -1,7 +1,12 @@
 package main
 
 import (
+	"cmp"
 	"context"
+	"fmt"
+	"net"
+	"slices"
+	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	mcpgolang "github.com/metoro-io/mcp-golang"
@@ -11,17 +16,934 @@ import (
 // It contains the LoadBalancer ID or Name that is needed to perform the lookup.
 type LoadBalancerReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The Load Balancer id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// LoadBalancerListArgs represents the arguments for listing LoadBalancers.
+// LoadBalancers can be filtered by name on top of the label selector.
+type LoadBalancerListArgs struct {
+	Name string `json:"name,omitempty" jsonschema:"description=Only return the load balancer with this name"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// LoadBalancerHealthCheckHTTPArgs represents the HTTP settings of a health check.
+type LoadBalancerHealthCheckHTTPArgs struct {
+	Domain      *string  `json:"domain,omitempty" jsonschema:"description=Host header sent with the health check"`
+	Path        *string  `json:"path,omitempty" jsonschema:"description=Path requested by the health check (default /)"`
+	Response    *string  `json:"response,omitempty" jsonschema:"description=Expected content of the response body"`
+	StatusCodes []string `json:"status_codes,omitempty" jsonschema:"description=Expected status codes with ? as wildcard (default 2?? and 3??)"`
+	TLS         *bool    `json:"tls,omitempty" jsonschema:"description=Whether the health check uses https (set for https health checks)"`
+}
+
+// LoadBalancerHealthCheckArgs represents the health check of a service.
+type LoadBalancerHealthCheckArgs struct {
+	Protocol string                           `json:"protocol,omitempty" jsonschema:"description=Protocol of the health check (tcp/http/https). Defaults to tcp for tcp services and http otherwise"`
+	Port     *int                             `json:"port,omitempty" jsonschema:"description=Port the health check connects to (default destination port)"`
+	Interval *int                             `json:"interval,omitempty" jsonschema:"description=Interval of the health check in seconds (default 15)"`
+	Timeout  *int                             `json:"timeout,omitempty" jsonschema:"description=Timeout of a health check in seconds (default 10)"`
+	Retries  *int                             `json:"retries,omitempty" jsonschema:"description=Number of failed checks before a target is unhealthy (default 3)"`
+	HTTP     *LoadBalancerHealthCheckHTTPArgs `json:"http,omitempty" jsonschema:"description=HTTP settings of http and https health checks"`
+}
+
+// LoadBalancerServiceHTTPArgs represents the HTTP settings of a service.
+type LoadBalancerServiceHTTPArgs struct {
+	CookieName     *string  `json:"cookie_name,omitempty" jsonschema:"description=Name of the cookie used for sticky sessions (default HCLBSTICKY)"`
+	CookieLifetime *int     `json:"cookie_lifetime,omitempty" jsonschema:"description=Lifetime of the sticky session cookie in seconds (default 300)"`
+	Certificates   []string `json:"certificates,omitempty" jsonschema:"description=Certificate ids or names of https services. Replaces all certificates of the service"`
+	RedirectHTTP   *bool    `json:"redirect_http,omitempty" jsonschema:"description=Redirect http requests on port 80 to https services"`
+	StickySessions *bool    `json:"sticky_sessions,omitempty" jsonschema:"description=Send the requests of a client to the same target"`
+}
+
+// LoadBalancerServiceArgs represents a service of a Load Balancer.
+// Unset fields keep their current value or get the default of the API.
+type LoadBalancerServiceArgs struct {
+	Protocol        string                       `json:"protocol,omitempty" jsonschema:"description=Protocol of the service (tcp/http/https). Required for new services"`
+	ListenPort      *int                         `json:"listen_port,omitempty" jsonschema:"description=Port the load balancer listens on. Identifies the service. Defaults to 80 for http and 443 for https"`
+	DestinationPort *int                         `json:"destination_port,omitempty" jsonschema:"description=Port traffic is forwarded to on the targets. Defaults to 80 for http and https"`
+	Proxyprotocol   *bool                        `json:"proxyprotocol,omitempty" jsonschema:"description=Whether the PROXY protocol is used"`
+	HTTP            *LoadBalancerServiceHTTPArgs `json:"http,omitempty" jsonschema:"description=HTTP settings of http and https services"`
+	HealthCheck     *LoadBalancerHealthCheckArgs `json:"health_check,omitempty" jsonschema:"description=Health check of the service"`
+}
+
+// LoadBalancerTargetArgs represents a target of a Load Balancer.
+type LoadBalancerTargetArgs struct {
+	Type          string `json:"type" jsonschema:"required,description=Type of the target (server/label_selector/ip)"`
+	Server        int64  `json:"server,omitempty" jsonschema:"description=The server id of server targets"`
+	LabelSelector string `json:"label_selector,omitempty" jsonschema:"description=The label selector of label_selector targets"`
+	IP            string `json:"ip,omitempty" jsonschema:"description=The public ip address of ip targets"`
+	UsePrivateIP  *bool  `json:"use_private_ip,omitempty" jsonschema:"description=Send traffic to the private ip of servers. Requires the load balancer to be attached to a network"`
+}
+
+// LoadBalancerCreateArgs contains the necessary fields to create a new load balancer,
+// including its type, location and optionally its services, targets and network.
+type LoadBalancerCreateArgs struct {
+	Name             string                    `json:"name" jsonschema:"required,description=The load balancer name"`
+	LoadBalancerType string                    `json:"load_balancer_type" jsonschema:"required,description=The load balancer type id or name (e.g. lb11)"`
+	Location         string                    `json:"location" jsonschema:"required,description=The location id or name to create the load balancer in (e.g. fsn1)"`
+	Algorithm        string                    `json:"algorithm,omitempty" jsonschema:"description=Algorithm distributing requests (round_robin/least_connections). Defaults to round_robin"`
+	Services         []LoadBalancerServiceArgs `json:"services,omitempty" jsonschema:"description=Services of the load balancer"`
+	Targets          []LoadBalancerTargetArgs  `json:"targets,omitempty" jsonschema:"description=Targets of the load balancer"`
+	Network          string                    `json:"network,omitempty" jsonschema:"description=The network id or name to attach the load balancer to"`
+	PublicInterface  *bool                     `json:"public_interface,omitempty" jsonschema:"description=Whether the public interface is enabled (defaults to true)"`
+	Labels           map[string]string         `json:"labels,omitempty" jsonschema:"description=User-defined labels for the load balancer"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// LoadBalancerActionArgs represents the arguments required to run an action on a Load Balancer.
+// It contains the Load Balancer ID the action is performed on.
+type LoadBalancerActionArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The load balancer id"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// LoadBalancerServiceChangeArgs represents the arguments required to add or update a service of a Load Balancer.
+type LoadBalancerServiceChangeArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The load balancer id"`
+	LoadBalancerServiceArgs
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// LoadBalancerServiceDeleteArgs represents the arguments required to delete a service of a Load Balancer.
+type LoadBalancerServiceDeleteArgs struct {
+	ID         int64 `json:"id" jsonschema:"required,description=The load balancer id"`
+	ListenPort int   `json:"listen_port" jsonschema:"required,description=Listen port of the service to delete"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// LoadBalancerTargetChangeArgs represents the arguments required to add a target to or remove a target from a Load Balancer.
+type LoadBalancerTargetChangeArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The load balancer id"`
+	LoadBalancerTargetArgs
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// LoadBalancerAlgorithmArgs represents the arguments required to change the algorithm of a Load Balancer.
+type LoadBalancerAlgorithmArgs struct {
+	ID        int64  `json:"id" jsonschema:"required,description=The load balancer id"`
+	Algorithm string `json:"algorithm" jsonschema:"required,description=Algorithm distributing requests (round_robin/least_connections)"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// LoadBalancerTypeChangeArgs represents the arguments required to change the type of a Load Balancer.
+type LoadBalancerTypeChangeArgs struct {
+	ID               int64  `json:"id" jsonschema:"required,description=The load balancer id"`
+	LoadBalancerType string `json:"load_balancer_type" jsonschema:"required,description=The new load balancer type id or name (e.g. lb21)"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// LoadBalancerNetworkArgs represents the arguments required to attach a Load Balancer to or detach it from a Network.
+type LoadBalancerNetworkArgs struct {
+	ID      int64  `json:"id" jsonschema:"required,description=The load balancer id"`
+	Network string `json:"network" jsonschema:"required,description=The network id or name"`
+	IP      string `json:"ip,omitempty" jsonschema:"description=IP address of the load balancer in the network when attaching. Assigned automatically if empty"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+type LoadBalancerPublicNetResponse struct {
+	Enabled bool   `json:"enabled" jsonschema:"required,description=Whether the public interface is enabled"`
+	IPv4    string `json:"ipv4" jsonschema:"description=Public IPv4 address"`
+	IPv6    string `json:"ipv6" jsonschema:"description=Public IPv6 address"`
+}
+
+type LoadBalancerPrivateNetResponse struct {
+	Network *ResourceRef `json:"network" jsonschema:"required,description=The network the load balancer is attached to"`
+	IP      string       `json:"ip" jsonschema:"required,description=IP address of the load balancer in the network"`
+}
+
+type LoadBalancerServiceHTTPResponse struct {
+	CookieName     string         `json:"cookie_name" jsonschema:"description=Name of the cookie used for sticky sessions"`
+	CookieLifetime int            `json:"cookie_lifetime" jsonschema:"description=Lifetime of the sticky session cookie in seconds"`
+	Certificates   []*ResourceRef `json:"certificates" jsonschema:"description=Certificates used for https services"`
+	RedirectHTTP   bool           `json:"redirect_http" jsonschema:"description=Whether http requests are redirected to https"`
+	StickySessions bool           `json:"sticky_sessions" jsonschema:"description=Whether sticky sessions are enabled"`
+}
+
+type LoadBalancerHealthCheckHTTPResponse struct {
+	Domain      string   `json:"domain" jsonschema:"description=Host header sent with the health check"`
+	Path        string   `json:"path" jsonschema:"description=Path requested by the health check"`
+	Response    string   `json:"response" jsonschema:"description=Expected content of the response body"`
+	StatusCodes []string `json:"status_codes" jsonschema:"description=Expected status codes (e.g. 2??)"`
+	TLS         bool     `json:"tls" jsonschema:"description=Whether the health check uses https"`
+}
+
+type LoadBalancerHealthCheckResponse struct {
+	Protocol string                               `json:"protocol" jsonschema:"required,description=Protocol of the health check (tcp/http/https)"`
+	Port     int                                  `json:"port" jsonschema:"required,description=Port the health check connects to"`
+	Interval int                                  `json:"interval" jsonschema:"required,description=Interval of the health check in seconds"`
+	Timeout  int                                  `json:"timeout" jsonschema:"required,description=Timeout of a health check in seconds"`
+	Retries  int                                  `json:"retries" jsonschema:"required,description=Number of failed checks before a target is unhealthy"`
+	HTTP     *LoadBalancerHealthCheckHTTPResponse `json:"http,omitempty" jsonschema:"description=HTTP settings of the health check"`
+}
+
+type LoadBalancerServiceResponse struct {
+	Protocol        string                           `json:"protocol" jsonschema:"required,description=Protocol of the service (tcp/http/https)"`
+	ListenPort      int                              `json:"listen_port" jsonschema:"required,description=Port the load balancer listens on"`
+	DestinationPort int                              `json:"destination_port" jsonschema:"required,description=Port traffic is forwarded to on the targets"`
+	Proxyprotocol   bool                             `json:"proxyprotocol" jsonschema:"required,description=Whether the PROXY protocol is used"`
+	HTTP            *LoadBalancerServiceHTTPResponse `json:"http,omitempty" jsonschema:"description=HTTP settings of http and https services"`
+	HealthCheck     LoadBalancerHealthCheckResponse  `json:"health_check" jsonschema:"required,description=Health check of the service"`
+}
+
+type LoadBalancerTargetHealthResponse struct {
+	ListenPort int    `json:"listen_port" jsonschema:"required,description=Listen port of the service"`
+	Status     string `json:"status" jsonschema:"required,description=Health of the target (healthy/unhealthy/unknown)"`
+}
+
+type LoadBalancerTargetResponse struct {
+	Type          string                             `json:"type" jsonschema:"required,description=Type of the target (server/label_selector/ip)"`
+	Server        *ResourceRef                       `json:"server,omitempty" jsonschema:"description=The server of server targets"`
+	LabelSelector string                             `json:"label_selector,omitempty" jsonschema:"description=The label selector of label_selector targets"`
+	IP            string                             `json:"ip,omitempty" jsonschema:"description=The ip address of ip targets"`
+	UsePrivateIP  bool                               `json:"use_private_ip" jsonschema:"description=Whether traffic is sent to the private ip of the servers"`
+	HealthStatus  []LoadBalancerTargetHealthResponse `json:"health_status" jsonschema:"description=Health of the target per service"`
+}
+
+type LoadBalancerResponse struct {
+	ID               int64                            `json:"id" jsonschema:"required,description=Unique identifier of the load balancer"`
+	Name             string                           `json:"name" jsonschema:"required,description=The name of the load balancer"`
+	PublicNet        LoadBalancerPublicNetResponse    `json:"public_net" jsonschema:"required,description=Public network of the load balancer"`
+	PrivateNet       []LoadBalancerPrivateNetResponse `json:"private_net" jsonschema:"description=Private networks the load balancer is attached to"`
+	Location         *ResourceRef                     `json:"location" jsonschema:"required,description=The location of the load balancer"`
+	LoadBalancerType *ResourceRef                     `json:"load_balancer_type" jsonschema:"required,description=The type of the load balancer"`
+	Algorithm        string                           `json:"algorithm" jsonschema:"required,description=Algorithm distributing requests (round_robin/least_connections)"`
+	Services         []LoadBalancerServiceResponse    `json:"services" jsonschema:"description=Services of the load balancer"`
+	Targets          []LoadBalancerTargetResponse     `json:"targets" jsonschema:"description=Targets of the load balancer"`
+	Protection       DeleteProtection                 `json:"protection" jsonschema:"required,description=Protection settings of the load balancer"`
+	Labels           map[string]string                `json:"labels" jsonschema:"description=User-defined labels for the load balancer"`
+	IncludedTraffic  uint64                           `json:"included_traffic" jsonschema:"required,description=Amount of included traffic in bytes"`
+	OutgoingTraffic  uint64                           `json:"outgoing_traffic" jsonschema:"required,description=Outgoing traffic in bytes"`
+	IngoingTraffic   uint64                           `json:"ingoing_traffic" jsonschema:"required,description=Ingoing traffic in bytes"`
+	Created          time.Time                        `json:"created" jsonschema:"required,description=Timestamp of when the load balancer was created"`
+}
+
+func toLoadBalancerResponse(l *hcloud.LoadBalancer) *LoadBalancerResponse {
+	if l == nil {
+		return nil
+	}
+
+	privateNet := make([]LoadBalancerPrivateNetResponse, 0, len(l.PrivateNet))
+	for _, p := range l.PrivateNet {
+		var network *ResourceRef
+		if p.Network != nil {
+			network = toResourceRef(p.Network.ID, p.Network.Name)
+		}
+		privateNet = append(privateNet, LoadBalancerPrivateNetResponse{Network: network, IP: ipString(p.IP)})
+	}
+
+	services := make([]LoadBalancerServiceResponse, 0, len(l.Services))
+	for _, s := range l.Services {
+		services = append(services, toLoadBalancerServiceResponse(s))
+	}
+
+	targets := make([]LoadBalancerTargetResponse, 0, len(l.Targets))
+	for _, t := range l.Targets {
+		targets = append(targets, toLoadBalancerTargetResponse(t))
+	}
+
+	var loadBalancerType *ResourceRef
+	if l.LoadBalancerType != nil {
+		loadBalancerType = toResourceRef(l.LoadBalancerType.ID, l.LoadBalancerType.Name)
+	}
+
+	return &LoadBalancerResponse{
+		ID:   l.ID,
+		Name: l.Name,
+		PublicNet: LoadBalancerPublicNetResponse{
+			Enabled: l.PublicNet.Enabled,
+			IPv4:    ipString(l.PublicNet.IPv4.IP),
+			IPv6:    ipString(l.PublicNet.IPv6.IP),
+		},
+		PrivateNet:       privateNet,
+		Location:         toLocationRef(l.Location),
+		LoadBalancerType: loadBalancerType,
+		Algorithm:        string(l.Algorithm.Type),
+		Services:         services,
+		Targets:          targets,
+		Protection:       DeleteProtection{Delete: l.Protection.Delete},
+		Labels:           l.Labels,
+		IncludedTraffic:  l.IncludedTraffic,
+		OutgoingTraffic:  l.OutgoingTraffic,
+		IngoingTraffic:   l.IngoingTraffic,
+		Created:          l.Created,
+	}
+}
+
+func toLoadBalancerServiceResponse(s hcloud.LoadBalancerService) LoadBalancerServiceResponse {
+	result := LoadBalancerServiceResponse{
+		Protocol:        string(s.Protocol),
+		ListenPort:      s.ListenPort,
+		DestinationPort: s.DestinationPort,
+		Proxyprotocol:   s.Proxyprotocol,
+		HealthCheck: LoadBalancerHealthCheckResponse{
+			Protocol: string(s.HealthCheck.Protocol),
+			Port:     s.HealthCheck.Port,
+			Interval: int(s.HealthCheck.Interval.Seconds()),
+			Timeout:  int(s.HealthCheck.Timeout.Seconds()),
+			Retries:  s.HealthCheck.Retries,
+		},
+	}
+
+	if s.Protocol != hcloud.LoadBalancerServiceProtocolTCP {
+		certificates := make([]*ResourceRef, 0, len(s.HTTP.Certificates))
+		for _, c := range s.HTTP.Certificates {
+			if c != nil {
+				certificates = append(certificates, toResourceRef(c.ID, c.Name))
+			}
+		}
+		result.HTTP = &LoadBalancerServiceHTTPResponse{
+			CookieName:     s.HTTP.CookieName,
+			CookieLifetime: int(s.HTTP.CookieLifetime.Seconds()),
+			Certificates:   certificates,
+			RedirectHTTP:   s.HTTP.RedirectHTTP,
+			StickySessions: s.HTTP.StickySessions,
+		}
+	}
+
+	if h := s.HealthCheck.HTTP; h != nil {
+		result.HealthCheck.HTTP = &LoadBalancerHealthCheckHTTPResponse{
+			Domain:      h.Domain,
+			Path:        h.Path,
+			Response:    h.Response,
+			StatusCodes: h.StatusCodes,
+			TLS:         h.TLS,
+		}
+	}
+
+	return result
+}
+
+func toLoadBalancerTargetResponse(t hcloud.LoadBalancerTarget) LoadBalancerTargetResponse {
+	result := LoadBalancerTargetResponse{
+		Type:         string(t.Type),
+		UsePrivateIP: t.UsePrivateIP,
+	}
+	if t.Server != nil {
+		result.Server = toServerRef(t.Server.Server)
+	}
+	if t.LabelSelector != nil {
+		result.LabelSelector = t.LabelSelector.Selector
+	}
+	if t.IP != nil {
+		result.IP = t.IP.IP
+	}
+	for _, h := range t.HealthStatus {
+		result.HealthStatus = append(result.HealthStatus, LoadBalancerTargetHealthResponse{
+			ListenPort: h.ListenPort,
+			Status:     string(h.Status),
+		})
+	}
+	return result
+}
+
+type LoadBalancerCreateResponse struct {
+	LoadBalancer *LoadBalancerResponse `json:"load_balancer" jsonschema:"required,description=The created load balancer"`
+	Action       *ActionResponse       `json:"action" jsonschema:"required,description=The action creating the load balancer"`
+}
+
+type LoadBalancerActionResponse struct {
+	LoadBalancer *LoadBalancerResponse `json:"load_balancer" jsonschema:"description=The load balancer the action was performed on; null if it was deleted"`
+	Action       *ActionResponse       `json:"action" jsonschema:"description=The triggered action; null if the change did not need one"`
+}
+
+var loadBalancerServiceProtocols = []hcloud.LoadBalancerServiceProtocol{
+	hcloud.LoadBalancerServiceProtocolTCP,
+	hcloud.LoadBalancerServiceProtocolHTTP,
+	hcloud.LoadBalancerServiceProtocolHTTPS,
+}
+
+var loadBalancerAlgorithms = []hcloud.LoadBalancerAlgorithmType{
+	hcloud.LoadBalancerAlgorithmTypeRoundRobin,
+	hcloud.LoadBalancerAlgorithmTypeLeastConnections,
+}
+
+func parseLoadBalancerProtocol(name, value string) (hcloud.LoadBalancerServiceProtocol, error) {
+	protocol := hcloud.LoadBalancerServiceProtocol(value)
+	if !slices.Contains(loadBalancerServiceProtocols, protocol) {
+		return protocol, fmt.Errorf("invalid %s %q, must be one of tcp, http, https", name, value)
+	}
+	return protocol, nil
+}
+
+func parseLoadBalancerAlgorithm(value string) (hcloud.LoadBalancerAlgorithmType, error) {
+	algorithm := hcloud.LoadBalancerAlgorithmType(value)
+	if !slices.Contains(loadBalancerAlgorithms, algorithm) {
+		return algorithm, fmt.Errorf("invalid algorithm %q, must be one of round_robin, least_connections", value)
+	}
+	return algorithm, nil
+}
+
+func checkPort(name string, port int) error {
+	if port < 1 || port > 65535 {
+		return fmt.Errorf("invalid %s %d, must be between 1 and 65535", name, port)
+	}
+	return nil
+}
+
+func checkSeconds(name string, seconds int) (time.Duration, error) {
+	if seconds < 1 {
+		return 0, fmt.Errorf("invalid %s %d, must be at least 1 second", name, seconds)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// applyLoadBalancerService applies the arguments to a service. New services start with the defaults of the API,
+// so the resulting service is the service the API is expected to have after the change.
+func applyLoadBalancerService(ctx context.Context, service *hcloud.LoadBalancerService, args LoadBalancerServiceArgs, isNew bool) error {
+	var err error
+	if args.Protocol != EmptyString {
+		service.Protocol, err = parseLoadBalancerProtocol("service protocol", args.Protocol)
+		if err != nil {
+			return err
+		}
+	}
+	if service.Protocol == EmptyString {
+		return fmt.Errorf("missing protocol of the service")
+	}
+	if isNew {
+		switch service.Protocol {
+		case hcloud.LoadBalancerServiceProtocolHTTP:
+			service.ListenPort, service.DestinationPort = 80, 80
+		case hcloud.LoadBalancerServiceProtocolHTTPS:
+			service.ListenPort, service.DestinationPort = 443, 80
+		}
+		service.HealthCheck = hcloud.LoadBalancerServiceHealthCheck{
+			Interval: 15 * time.Second,
+			Timeout:  10 * time.Second,
+			Retries:  3,
+		}
+	}
+	if args.ListenPort != nil {
+		service.ListenPort = *args.ListenPort
+	}
+	if args.DestinationPort != nil {
+		service.DestinationPort = *args.DestinationPort
+	}
+	if service.ListenPort == 0 || service.DestinationPort == 0 {
+		return fmt.Errorf("%s services require a listen_port and a destination_port", service.Protocol)
+	}
+	if err := checkPort("listen port", service.ListenPort); err != nil {
+		return err
+	}
+	if err := checkPort("destination port", service.DestinationPort); err != nil {
+		return err
+	}
+	if args.Proxyprotocol != nil {
+		service.Proxyprotocol = *args.Proxyprotocol
+	}
+
+	if service.Protocol == hcloud.LoadBalancerServiceProtocolTCP {
+		if args.HTTP != nil {
+			return fmt.Errorf("http settings are only supported for http and https services")
+		}
+		service.HTTP = hcloud.LoadBalancerServiceHTTP{}
+	} else {
+		if service.HTTP.CookieName == EmptyString {
+			service.HTTP.CookieName = "HCLBSTICKY"
+			service.HTTP.CookieLifetime = 300 * time.Second
+		}
+		if h := args.HTTP; h != nil {
+			if h.CookieName != nil {
+				service.HTTP.CookieName = *h.CookieName
+			}
+			if h.CookieLifetime != nil {
+				service.HTTP.CookieLifetime, err = checkSeconds("cookie lifetime", *h.CookieLifetime)
+				if err != nil {
+					return err
+				}
+			}
+			if h.RedirectHTTP != nil {
+				service.HTTP.RedirectHTTP = *h.RedirectHTTP
+			}
+			if h.StickySessions != nil {
+				service.HTTP.StickySessions = *h.StickySessions
+			}
+			if h.Certificates != nil {
+				certificates := make([]*hcloud.Certificate, 0, len(h.Certificates))
+				for _, idOrName := range h.Certificates {
+					certificate, _, err := hcloudClient(ctx).Certificate.Get(ctx, idOrName)
+					if err != nil {
+						return err
+					}
+					if certificate == nil {
+						return fmt.Errorf("certificate %s not found", idOrName)
+					}
+					if err := checkScope("certificate", certificate.ID, certificate.Labels); err != nil {
+						return err
+					}
+					certificates = append(certificates, certificate)
+				}
+				service.HTTP.Certificates = certificates
+			}
+		}
+		switch service.Protocol {
+		case hcloud.LoadBalancerServiceProtocolHTTPS:
+			if len(service.HTTP.Certificates) == 0 {
+				return fmt.Errorf("https services require at least one certificate")
+			}
+		case hcloud.LoadBalancerServiceProtocolHTTP:
+			if len(service.HTTP.Certificates) > 0 || service.HTTP.RedirectHTTP {
+				return fmt.Errorf("certificates and redirect_http are only supported for https services")
+			}
+		}
+	}
+
+	healthCheck := &service.HealthCheck
+	if isNew {
+		healthCheck.Protocol = hcloud.LoadBalancerServiceProtocolHTTP
+		if service.Protocol == hcloud.LoadBalancerServiceProtocolTCP {
+			healthCheck.Protocol = hcloud.LoadBalancerServiceProtocolTCP
+		}
+		healthCheck.Port = service.DestinationPort
+	}
+	a := cmp.Or(args.HealthCheck, &LoadBalancerHealthCheckArgs{})
+	if a.Protocol != EmptyString {
+		healthCheck.Protocol, err = parseLoadBalancerProtocol("health check protocol", a.Protocol)
+		if err != nil {
+			return err
+		}
+	}
+	if a.Port != nil {
+		if err := checkPort("health check port", *a.Port); err != nil {
+			return err
+		}
+		healthCheck.Port = *a.Port
+	}
+	if a.Interval != nil {
+		healthCheck.Interval, err = checkSeconds("health check interval", *a.Interval)
+		if err != nil {
+			return err
+		}
+	}
+	if a.Timeout != nil {
+		healthCheck.Timeout, err = checkSeconds("health check timeout", *a.Timeout)
+		if err != nil {
+			return err
+		}
+	}
+	if a.Retries != nil {
+		if *a.Retries < 0 {
+			return fmt.Errorf("invalid health check retries %d, must not be negative", *a.Retries)
+		}
+		healthCheck.Retries = *a.Retries
+	}
+	if healthCheck.Protocol == hcloud.LoadBalancerServiceProtocolTCP {
+		if a.HTTP != nil {
+			return fmt.Errorf("http settings are only supported for http and https health checks")
+		}
+		healthCheck.HTTP = nil
+		return nil
+	}
+	// Copy the http settings, so that the current state of the service is not changed
+	http := hcloud.LoadBalancerServiceHealthCheckHTTP{Path: "/", StatusCodes: []string{"2??", "3??"}}
+	if healthCheck.HTTP != nil {
+		http = *healthCheck.HTTP
+	}
+	if h := a.HTTP; h != nil {
+		if h.Domain != nil {
+			http.Domain = *h.Domain
+		}
+		if h.Path != nil {
+			http.Path = *h.Path
+		}
+		if h.Response != nil {
+			http.Response = *h.Response
+		}
+		if h.StatusCodes != nil {
+			http.StatusCodes = h.StatusCodes
+		}
+		if h.TLS != nil {
+			http.TLS = *h.TLS
+		}
+	}
+	healthCheck.HTTP = &http
+	return nil
+}
+
+func toLoadBalancerAddServiceOpts(service hcloud.LoadBalancerService) hcloud.LoadBalancerAddServiceOpts {
+	opts := hcloud.LoadBalancerAddServiceOpts{
+		Protocol:        service.Protocol,
+		ListenPort:      hcloud.Ptr(service.ListenPort),
+		DestinationPort: hcloud.Ptr(service.DestinationPort),
+		Proxyprotocol:   hcloud.Ptr(service.Proxyprotocol),
+		HealthCheck: &hcloud.LoadBalancerAddServiceOptsHealthCheck{
+			Protocol: service.HealthCheck.Protocol,
+			Port:     hcloud.Ptr(service.HealthCheck.Port),
+			Interval: hcloud.Ptr(service.HealthCheck.Interval),
+			Timeout:  hcloud.Ptr(service.HealthCheck.Timeout),
+			Retries:  hcloud.Ptr(service.HealthCheck.Retries),
+		},
+	}
+	if service.Protocol != hcloud.LoadBalancerServiceProtocolTCP {
+		opts.HTTP = &hcloud.LoadBalancerAddServiceOptsHTTP{
+			CookieName:     hcloud.Ptr(service.HTTP.CookieName),
+			CookieLifetime: hcloud.Ptr(service.HTTP.CookieLifetime),
+			Certificates:   service.HTTP.Certificates,
+			RedirectHTTP:   hcloud.Ptr(service.HTTP.RedirectHTTP),
+			StickySessions: hcloud.Ptr(service.HTTP.StickySessions),
+		}
+	}
+	if h := service.HealthCheck.HTTP; h != nil {
+		opts.HealthCheck.HTTP = &hcloud.LoadBalancerAddServiceOptsHealthCheckHTTP{
+			Domain:      hcloud.Ptr(h.Domain),
+			Path:        hcloud.Ptr(h.Path),
+			Response:    hcloud.Ptr(h.Response),
+			StatusCodes: h.StatusCodes,
+			TLS:         hcloud.Ptr(h.TLS),
+		}
+	}
+	return opts
+}
+
+func toLoadBalancerCreateOptsService(service hcloud.LoadBalancerService) hcloud.LoadBalancerCreateOptsService {
+	opts := toLoadBalancerAddServiceOpts(service)
+	return hcloud.LoadBalancerCreateOptsService{
+		Protocol:        opts.Protocol,
+		ListenPort:      opts.ListenPort,
+		DestinationPort: opts.DestinationPort,
+		Proxyprotocol:   opts.Proxyprotocol,
+		HTTP:            (*hcloud.LoadBalancerCreateOptsServiceHTTP)(opts.HTTP),
+		HealthCheck: &hcloud.LoadBalancerCreateOptsServiceHealthCheck{
+			Protocol: opts.HealthCheck.Protocol,
+			Port:     opts.HealthCheck.Port,
+			Interval: opts.HealthCheck.Interval,
+			Timeout:  opts.HealthCheck.Timeout,
+			Retries:  opts.HealthCheck.Retries,
+			HTTP:     (*hcloud.LoadBalancerCreateOptsServiceHealthCheckHTTP)(opts.HealthCheck.HTTP),
+		},
+	}
+}
+
+func toLoadBalancerUpdateServiceOpts(service hcloud.LoadBalancerService) hcloud.LoadBalancerUpdateServiceOpts {
+	opts := toLoadBalancerAddServiceOpts(service)
+	return hcloud.LoadBalancerUpdateServiceOpts{
+		Protocol:        opts.Protocol,
+		DestinationPort: opts.DestinationPort,
+		Proxyprotocol:   opts.Proxyprotocol,
+		HTTP:            (*hcloud.LoadBalancerUpdateServiceOptsHTTP)(opts.HTTP),
+		HealthCheck: &hcloud.LoadBalancerUpdateServiceOptsHealthCheck{
+			Protocol: opts.HealthCheck.Protocol,
+			Port:     opts.HealthCheck.Port,
+			Interval: opts.HealthCheck.Interval,
+			Timeout:  opts.HealthCheck.Timeout,
+			Retries:  opts.HealthCheck.Retries,
+			HTTP:     (*hcloud.LoadBalancerUpdateServiceOptsHealthCheckHTTP)(opts.HealthCheck.HTTP),
+		},
+	}
+}
+
+// toLoadBalancerTarget validates a target of a Load Balancer attached to the given networks.
+// Label selectors are narrowed down to the label scope.
+func toLoadBalancerTarget(ctx context.Context, networks []int64, args LoadBalancerTargetArgs) (hcloud.LoadBalancerTarget, error) {
+	target := hcloud.LoadBalancerTarget{Type: hcloud.LoadBalancerTargetType(args.Type)}
+	if args.UsePrivateIP != nil {
+		target.UsePrivateIP = *args.UsePrivateIP
+	}
+	if target.UsePrivateIP && len(networks) == 0 {
+		return target, fmt.Errorf("use_private_ip requires the load balancer to be attached to a network")
+	}
+	if (args.Server != 0) != (target.Type == hcloud.LoadBalancerTargetTypeServer) ||
+		(args.LabelSelector != EmptyString) != (target.Type == hcloud.LoadBalancerTargetTypeLabelSelector) ||
+		(args.IP != EmptyString) != (target.Type == hcloud.LoadBalancerTargetTypeIP) {
+		return target, fmt.Errorf("%s targets require exactly one of server, label_selector or ip matching their type", args.Type)
+	}
+
+	switch target.Type {
+	case hcloud.LoadBalancerTargetTypeServer:
+		server, err := getServer(ctx, args.Server)
+		if err != nil {
+			return target, err
+		}
+		if err := checkScope("server", server.ID, server.Labels); err != nil {
+			return target, err
+		}
+		if target.UsePrivateIP && !slices.ContainsFunc(server.PrivateNet, func(p hcloud.ServerPrivateNet) bool {
+			return p.Network != nil && slices.Contains(networks, p.Network.ID)
+		}) {
+			return target, fmt.Errorf("server %d is not attached to a network of the load balancer, it cannot use its private ip", server.ID)
+		}
+		target.Server = &hcloud.LoadBalancerTargetServer{Server: server}
+	case hcloud.LoadBalancerTargetTypeLabelSelector:
+		if _, err := parseLabelSelector(args.LabelSelector); err != nil {
+			return target, err
+		}
+		target.LabelSelector = &hcloud.LoadBalancerTargetLabelSelector{Selector: scopeLabelSelector(args.LabelSelector)}
+	case hcloud.LoadBalancerTargetTypeIP:
+		ip, err := parseIP("target IP", args.IP)
+		if err != nil {
+			return target, err
+		}
+		if target.UsePrivateIP || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+			return target, fmt.Errorf("ip targets must be public ip addresses, use server targets for private ips")
+		}
+		target.IP = &hcloud.LoadBalancerTargetIP{IP: ip.String()}
+	default:
+		return target, fmt.Errorf("invalid target type %q, must be one of server, label_selector, ip", args.Type)
+	}
+	return target, nil
+}
+
+// matchesLoadBalancerTarget reports whether the target is the target given by the arguments.
+func matchesLoadBalancerTarget(target hcloud.LoadBalancerTarget, args LoadBalancerTargetArgs) bool {
+	if string(target.Type) != args.Type {
+		return false
+	}
+	switch {
+	case target.Server != nil && target.Server.Server != nil:
+		return target.Server.Server.ID == args.Server
+	case target.LabelSelector != nil:
+		return target.LabelSelector.Selector == args.LabelSelector || target.LabelSelector.Selector == scopeLabelSelector(args.LabelSelector)
+	case target.IP != nil:
+		return net.ParseIP(target.IP.IP).Equal(net.ParseIP(args.IP))
+	}
+	return false
+}
+
+func toLoadBalancerCreateOptsTarget(target hcloud.LoadBalancerTarget) hcloud.LoadBalancerCreateOptsTarget {
+	opts := hcloud.LoadBalancerCreateOptsTarget{
+		Type:         target.Type,
+		UsePrivateIP: hcloud.Ptr(target.UsePrivateIP),
+	}
+	if target.Server != nil {
+		opts.Server.Server = target.Server.Server
+	}
+	if target.LabelSelector != nil {
+		opts.LabelSelector.Selector = target.LabelSelector.Selector
+	}
+	if target.IP != nil {
+		opts.IP.IP = target.IP.IP
+	}
+	return opts
+}
+
+// checkLoadBalancerServices fails if services listen on the same port. A https service redirecting
+// http occupies port 80 as well.
+func checkLoadBalancerServices(services []hcloud.LoadBalancerService) error {
+	ports := make(map[int]bool, len(services))
+	for _, service := range services {
+		if ports[service.ListenPort] {
+			return fmt.Errorf("listen port %d is used by more than one service", service.ListenPort)
+		}
+		ports[service.ListenPort] = true
+	}
+	for _, service := range services {
+		if service.HTTP.RedirectHTTP && ports[80] {
+			return fmt.Errorf("redirect_http of the service on listen port %d needs port 80, which is used by another service", service.ListenPort)
+		}
+	}
+	return nil
+}
+
+// checkLoadBalancerLimits fails if the Load Balancer has more services or targets than its type supports.
+func checkLoadBalancerLimits(loadBalancerType *hcloud.LoadBalancerType, services, targets int) error {
+	if loadBalancerType == nil {
+		return nil
+	}
+	if loadBalancerType.MaxServices > 0 && services > loadBalancerType.MaxServices {
+		return fmt.Errorf("load balancer type %s supports at most %d services", loadBalancerType.Name, loadBalancerType.MaxServices)
+	}
+	if loadBalancerType.MaxTargets > 0 && targets > loadBalancerType.MaxTargets {
+		return fmt.Errorf("load balancer type %s supports at most %d targets", loadBalancerType.Name, loadBalancerType.MaxTargets)
+	}
+	return nil
+}
+
+func getLoadBalancerType(ctx context.Context, idOrName string) (*hcloud.LoadBalancerType, error) {
+	loadBalancerType, _, err := hcloudClient(ctx).LoadBalancerType.Get(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if loadBalancerType == nil {
+		return nil, fmt.Errorf("load balancer type %s not found", idOrName)
+	}
+	return loadBalancerType, nil
+}
+
+// toLoadBalancerCreateOpts validates the arguments for creating a Load Balancer.
+// It returns the options and the Load Balancer they are expected to create.
+func toLoadBalancerCreateOpts(ctx context.Context, args LoadBalancerCreateArgs) (hcloud.LoadBalancerCreateOpts, *hcloud.LoadBalancer, error) {
+	opts := hcloud.LoadBalancerCreateOpts{
+		Name:            args.Name,
+		PublicInterface: args.PublicInterface,
+	}
+	expected := &hcloud.LoadBalancer{
+		Name:      args.Name,
+		PublicNet: hcloud.LoadBalancerPublicNet{Enabled: args.PublicInterface == nil || *args.PublicInterface},
+		Created:   time.Now(),
+	}
+	if args.Name == EmptyString {
+		return opts, nil, fmt.Errorf("missing load balancer name")
+	}
+	labels, err := scopeLabels(args.Labels)
+	if err != nil {
+		return opts, nil, err
+	}
+	opts.Labels, expected.Labels = labels, labels
+
+	opts.LoadBalancerType, err = getLoadBalancerType(ctx, args.LoadBalancerType)
+	if err != nil {
+		return opts, nil, err
+	}
+	expected.LoadBalancerType = opts.LoadBalancerType
+
+	location, _, err := hcloudClient(ctx).Location.Get(ctx, args.Location)
+	if err != nil {
+		return opts, nil, err
+	}
+	if location == nil {
+		return opts, nil, fmt.Errorf("location %s not found", args.Location)
+	}
+	opts.Location, expected.Location = location, location
+
+	algorithm, err := parseLoadBalancerAlgorithm(cmp.Or(args.Algorithm, string(hcloud.LoadBalancerAlgorithmTypeRoundRobin)))
+	if err != nil {
+		return opts, nil, err
+	}
+	opts.Algorithm = &hcloud.LoadBalancerAlgorithm{Type: algorithm}
+	expected.Algorithm = *opts.Algorithm
+
+	var networks []int64
+	if args.Network != EmptyString {
+		network, err := getNetwork(ctx, args.Network)
+		if err != nil {
+			return opts, nil, err
+		}
+		if err := checkScope("network", network.ID, network.Labels); err != nil {
+			return opts, nil, err
+		}
+		if len(zoneSubnets(network, location.NetworkZone)) == 0 {
+			return opts, nil, fmt.Errorf("network %d has no subnet in the network zone %s of location %s", network.ID, location.NetworkZone, location.Name)
+		}
+		opts.Network = network
+		networks = append(networks, network.ID)
+		expected.PrivateNet = []hcloud.LoadBalancerPrivateNet{{Network: network}}
+	} else if !expected.PublicNet.Enabled {
+		return opts, nil, fmt.Errorf("a load balancer without public interface must be attached to a network")
+	}
+
+	for _, serviceArgs := range args.Services {
+		var service hcloud.LoadBalancerService
+		if err := applyLoadBalancerService(ctx, &service, serviceArgs, true); err != nil {
+			return opts, nil, err
+		}
+		expected.Services = append(expected.Services, service)
+		opts.Services = append(opts.Services, toLoadBalancerCreateOptsService(service))
+	}
+	if err := checkLoadBalancerServices(expected.Services); err != nil {
+		return opts, nil, err
+	}
+	for _, targetArgs := range args.Targets {
+		if slices.ContainsFunc(expected.Targets, func(t hcloud.LoadBalancerTarget) bool { return matchesLoadBalancerTarget(t, targetArgs) }) {
+			return opts, nil, fmt.Errorf("%s target is given more than once", targetArgs.Type)
+		}
+		target, err := toLoadBalancerTarget(ctx, networks, targetArgs)
+		if err != nil {
+			return opts, nil, err
+		}
+		expected.Targets = append(expected.Targets, target)
+		opts.Targets = append(opts.Targets, toLoadBalancerCreateOptsTarget(target))
+	}
+	if err := checkLoadBalancerLimits(opts.LoadBalancerType, len(expected.Services), len(expected.Targets)); err != nil {
+		return opts, nil, err
+	}
+
+	return opts, expected, nil
+}
+
+// planLoadBalancerCreate validates the options for creating a Load Balancer and returns the planned change.
+func planLoadBalancerCreate(ctx context.Context, opts hcloud.LoadBalancerCreateOpts, expected *hcloud.LoadBalancer) (*PlannedChange, error) {
+	var warnings []string
+
+	existing, _, err := hcloudClient(ctx).LoadBalancer.GetByName(ctx, opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("load balancer %s already exists", opts.Name)
+	}
+	if opts.LoadBalancerType.Deprecated != nil {
+		warnings = append(warnings, fmt.Sprintf("load balancer type %s is deprecated", opts.LoadBalancerType.Name))
+	}
+
+	return newPlannedChange("create_load_balancer", &LoadBalancerCreateResponse{
+		LoadBalancer: toLoadBalancerResponse(expected),
+		Action:       plannedAction("create_load_balancer"),
+	}, warnings...), nil
+}
+
+// getLoadBalancer retrieves a Load Balancer by its ID and fails if the Load Balancer does not exist.
+func getLoadBalancer(ctx context.Context, id int64) (*hcloud.LoadBalancer, error) {
+	loadBalancer, _, err := hcloudClient(ctx).LoadBalancer.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if loadBalancer == nil {
+		return nil, fmt.Errorf("load balancer %d not found", id)
+	}
+	return loadBalancer, nil
+}
+
+// loadBalancerNetworks returns the IDs of the networks the Load Balancer is attached to.
+func loadBalancerNetworks(loadBalancer *hcloud.LoadBalancer) []int64 {
+	networks := make([]int64, 0, len(loadBalancer.PrivateNet))
+	for _, p := range loadBalancer.PrivateNet {
+		if p.Network != nil {
+			networks = append(networks, p.Network.ID)
+		}
+	}
+	return networks
+}
+
+// loadBalancerTarget runs actions on Load Balancers with runAction.
+var loadBalancerTarget = actionTarget[hcloud.LoadBalancer, hcloud.LoadBalancer]{
+	name:       "load balancer",
+	actionType: "load_balancer",
+	get:        getLoadBalancer,
+	labels:     func(loadBalancer *hcloud.LoadBalancer) map[string]string { return loadBalancer.Labels },
+	convert:    func(loadBalancer *hcloud.LoadBalancer) *hcloud.LoadBalancer { return loadBalancer },
+	respond: func(loadBalancer *hcloud.LoadBalancer, actions []*ActionResponse) any {
+		return &LoadBalancerActionResponse{LoadBalancer: toLoadBalancerResponse(loadBalancer), Action: firstAction(actions)}
+	},
 }
 
 // LoadBalancerTools
 var loadBalancerTools = []Tool{
 	{
 		Name:        "get_all_load_balancers",
-		Description: "Returns all LoadBalancers objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.LoadBalancer, error) {
-				result, err := client.LoadBalancer.All(context.Background())
-				return result, err
+		Description: "Returns a page of LoadBalancers, optionally filtered by name and label selector.",
+		Handler: func(ctx context.Context, args LoadBalancerListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*LoadBalancerResponse], error) {
+				listOpts, err := args.listOpts()
+				if err != nil {
+					return nil, err
+				}
+				opts := hcloud.LoadBalancerListOpts{
+					ListOpts: listOpts,
+					Name:     args.Name,
+				}
+				result, resp, err := hcloudClient(ctx).LoadBalancer.List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return newListResponse(toResponseList(result, toLoadBalancerResponse), resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -29,12 +951,424 @@ var loadBalancerTools = []Tool{
 	{
 		Name:        "get_a_load_balancer_by_id_or_name",
 		Description: "Retrieves a LoadBalancer by its ID or Name. Get retrieves a load balancer by its ID if the input can be parsed as an integer, otherwise it retrieves a load balancer by its name. If the load balancer does not exist, nil is returned.",
-		Handler: func(args LoadBalancerReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.LoadBalancer, error) {
-				result, _, err := client.LoadBalancer.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args LoadBalancerReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*LoadBalancerResponse, error) {
+				result, _, err := hcloudClient(ctx).LoadBalancer.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return toLoadBalancerResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
 	},
+	{
+		Name:        "create_a_load_balancer",
+		Description: "Creates a new Load Balancer in a location with optional services and targets. It can be attached to a network right away; targets can then be reached by their private IPs.",
+		Handler: func(ctx context.Context, args LoadBalancerCreateArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				opts, expected, err := toLoadBalancerCreateOpts(ctx, args)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					return planLoadBalancerCreate(ctx, opts, expected)
+				}
+				result, _, err := hcloudClient(ctx).LoadBalancer.Create(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				recordActions(ctx, result.Action)
+				if args.Wait {
+					result.Action, err = waitForAction(ctx, result.Action)
+					if err != nil {
+						return nil, err
+					}
+					result.LoadBalancer, _, err = hcloudClient(ctx).LoadBalancer.GetByID(ctx, result.LoadBalancer.ID)
+					if err != nil {
+						return nil, err
+					}
+				}
+				return &LoadBalancerCreateResponse{
+					LoadBalancer: toLoadBalancerResponse(result.LoadBalancer),
+					Action:       toActionResponse(result.Action),
+				}, nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_a_load_balancer",
+		Description: "Deletes a Load Balancer. Its services stop and its IPs are released. It cannot be undone.",
+		Handler: func(ctx context.Context, args LoadBalancerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "delete_load_balancer", true,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					if loadBalancer.Protection.Delete {
+						return nil, nil, fmt.Errorf("load balancer %d is protected against deletion", loadBalancer.ID)
+					}
+					var warnings []string
+					if len(loadBalancer.Services) > 0 {
+						warnings = append(warnings, fmt.Sprintf("%d services of load balancer %d will stop", len(loadBalancer.Services), loadBalancer.ID))
+					}
+					return nil, warnings, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					_, err := hcloudClient(ctx).LoadBalancer.Delete(ctx, loadBalancer)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "add_service_to_load_balancer",
+		Description: "Adds a tcp/http/https service to a Load Balancer. Ports and health checks default to the API defaults, https services need a certificate.",
+		Handler: func(ctx context.Context, args LoadBalancerServiceChangeArgs) (*mcpgolang.ToolResponse, error) {
+			var service hcloud.LoadBalancerService
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "add_service", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					if err := applyLoadBalancerService(ctx, &service, args.LoadBalancerServiceArgs, true); err != nil {
+						return nil, nil, err
+					}
+					loadBalancer.Services = append(slices.Clone(loadBalancer.Services), service)
+					if err := checkLoadBalancerServices(loadBalancer.Services); err != nil {
+						return nil, nil, err
+					}
+					if err := checkLoadBalancerLimits(loadBalancer.LoadBalancerType, len(loadBalancer.Services), len(loadBalancer.Targets)); err != nil {
+						return nil, nil, err
+					}
+					var warnings []string
+					if len(loadBalancer.Targets) == 0 {
+						warnings = append(warnings, fmt.Sprintf("load balancer %d has no targets yet", loadBalancer.ID))
+					}
+					return loadBalancer, warnings, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).LoadBalancer.AddService(ctx, loadBalancer, toLoadBalancerAddServiceOpts(service))
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "update_load_balancer_service",
+		Description: "Updates the service of a Load Balancer listening on listen_port. Only the given settings are changed, the listen port itself cannot be changed.",
+		Handler: func(ctx context.Context, args LoadBalancerServiceChangeArgs) (*mcpgolang.ToolResponse, error) {
+			var service hcloud.LoadBalancerService
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "update_service", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					if args.ListenPort == nil {
+						return nil, nil, fmt.Errorf("missing listen_port of the service to update")
+					}
+					i := slices.IndexFunc(loadBalancer.Services, func(s hcloud.LoadBalancerService) bool { return s.ListenPort == *args.ListenPort })
+					if i < 0 {
+						return nil, nil, fmt.Errorf("load balancer %d has no service on listen port %d", loadBalancer.ID, *args.ListenPort)
+					}
+					service = loadBalancer.Services[i]
+					if err := applyLoadBalancerService(ctx, &service, args.LoadBalancerServiceArgs, false); err != nil {
+						return nil, nil, err
+					}
+					loadBalancer.Services = slices.Clone(loadBalancer.Services)
+					loadBalancer.Services[i] = service
+					if err := checkLoadBalancerServices(loadBalancer.Services); err != nil {
+						return nil, nil, err
+					}
+					return loadBalancer, nil, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).LoadBalancer.UpdateService(ctx, loadBalancer, service.ListenPort, toLoadBalancerUpdateServiceOpts(service))
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_service_from_load_balancer",
+		Description: "Deletes the service of a Load Balancer listening on listen_port.",
+		Handler: func(ctx context.Context, args LoadBalancerServiceDeleteArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "delete_service", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					i := slices.IndexFunc(loadBalancer.Services, func(s hcloud.LoadBalancerService) bool { return s.ListenPort == args.ListenPort })
+					if i < 0 {
+						return nil, nil, fmt.Errorf("load balancer %d has no service on listen port %d", loadBalancer.ID, args.ListenPort)
+					}
+					loadBalancer.Services = slices.Delete(slices.Clone(loadBalancer.Services), i, i+1)
+					return loadBalancer, nil, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).LoadBalancer.DeleteService(ctx, loadBalancer, args.ListenPort)
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "add_target_to_load_balancer",
+		Description: "Adds a server, label selector or public IP target to a Load Balancer. Private IPs of targets can only be used if the load balancer and the servers are attached to the same network.",
+		Handler: func(ctx context.Context, args LoadBalancerTargetChangeArgs) (*mcpgolang.ToolResponse, error) {
+			var target hcloud.LoadBalancerTarget
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "add_target", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					if slices.ContainsFunc(loadBalancer.Targets, func(t hcloud.LoadBalancerTarget) bool {
+						return matchesLoadBalancerTarget(t, args.LoadBalancerTargetArgs)
+					}) {
+						return nil, nil, fmt.Errorf("load balancer %d already has this %s target", loadBalancer.ID, args.Type)
+					}
+					var err error
+					target, err = toLoadBalancerTarget(ctx, loadBalancerNetworks(loadBalancer), args.LoadBalancerTargetArgs)
+					if err != nil {
+						return nil, nil, err
+					}
+					loadBalancer.Targets = append(slices.Clone(loadBalancer.Targets), target)
+					if err := checkLoadBalancerLimits(loadBalancer.LoadBalancerType, len(loadBalancer.Services), len(loadBalancer.Targets)); err != nil {
+						return nil, nil, err
+					}
+					return loadBalancer, nil, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					var result *hcloud.Action
+					var err error
+					switch target.Type {
+					case hcloud.LoadBalancerTargetTypeServer:
+						result, _, err = hcloudClient(ctx).LoadBalancer.AddServerTarget(ctx, loadBalancer, hcloud.LoadBalancerAddServerTargetOpts{
+							Server:       target.Server.Server,
+							UsePrivateIP: hcloud.Ptr(target.UsePrivateIP),
+						})
+					case hcloud.LoadBalancerTargetTypeLabelSelector:
+						result, _, err = hcloudClient(ctx).LoadBalancer.AddLabelSelectorTarget(ctx, loadBalancer, hcloud.LoadBalancerAddLabelSelectorTargetOpts{
+							Selector:     target.LabelSelector.Selector,
+							UsePrivateIP: hcloud.Ptr(target.UsePrivateIP),
+						})
+					case hcloud.LoadBalancerTargetTypeIP:
+						result, _, err = hcloudClient(ctx).LoadBalancer.AddIPTarget(ctx, loadBalancer, hcloud.LoadBalancerAddIPTargetOpts{
+							IP: net.ParseIP(target.IP.IP),
+						})
+					}
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "remove_target_from_load_balancer",
+		Description: "Removes a server, label selector or IP target from a Load Balancer.",
+		Handler: func(ctx context.Context, args LoadBalancerTargetChangeArgs) (*mcpgolang.ToolResponse, error) {
+			var target hcloud.LoadBalancerTarget
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "remove_target", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					i := slices.IndexFunc(loadBalancer.Targets, func(t hcloud.LoadBalancerTarget) bool {
+						return matchesLoadBalancerTarget(t, args.LoadBalancerTargetArgs)
+					})
+					if i < 0 {
+						return nil, nil, fmt.Errorf("load balancer %d has no such %s target", loadBalancer.ID, args.Type)
+					}
+					target = loadBalancer.Targets[i]
+					loadBalancer.Targets = slices.Delete(slices.Clone(loadBalancer.Targets), i, i+1)
+					var warnings []string
+					if len(loadBalancer.Targets) == 0 && len(loadBalancer.Services) > 0 {
+						warnings = append(warnings, fmt.Sprintf("load balancer %d will have no targets left", loadBalancer.ID))
+					}
+					return loadBalancer, warnings, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					var result *hcloud.Action
+					var err error
+					switch {
+					case target.Server != nil:
+						result, _, err = hcloudClient(ctx).LoadBalancer.RemoveServerTarget(ctx, loadBalancer, target.Server.Server)
+					case target.LabelSelector != nil:
+						result, _, err = hcloudClient(ctx).LoadBalancer.RemoveLabelSelectorTarget(ctx, loadBalancer, target.LabelSelector.Selector)
+					case target.IP != nil:
+						result, _, err = hcloudClient(ctx).LoadBalancer.RemoveIPTarget(ctx, loadBalancer, net.ParseIP(target.IP.IP))
+					}
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "change_load_balancer_algorithm",
+		Description: "Changes the algorithm a Load Balancer distributes requests with (round_robin/least_connections).",
+		Handler: func(ctx context.Context, args LoadBalancerAlgorithmArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "change_algorithm", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					algorithm, err := parseLoadBalancerAlgorithm(args.Algorithm)
+					if err != nil {
+						return nil, nil, err
+					}
+					var warnings []string
+					if loadBalancer.Algorithm.Type == algorithm {
+						warnings = append(warnings, fmt.Sprintf("load balancer %d already uses %s", loadBalancer.ID, algorithm))
+					}
+					loadBalancer.Algorithm = hcloud.LoadBalancerAlgorithm{Type: algorithm}
+					return loadBalancer, warnings, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).LoadBalancer.ChangeAlgorithm(ctx, loadBalancer, hcloud.LoadBalancerChangeAlgorithmOpts{
+						Type: hcloud.LoadBalancerAlgorithmType(args.Algorithm),
+					})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "change_load_balancer_type",
+		Description: "Changes the type of a Load Balancer. The new type must support the current number of services and targets.",
+		Handler: func(ctx context.Context, args LoadBalancerTypeChangeArgs) (*mcpgolang.ToolResponse, error) {
+			var loadBalancerType *hcloud.LoadBalancerType
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "change_type", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					var err error
+					loadBalancerType, err = getLoadBalancerType(ctx, args.LoadBalancerType)
+					if err != nil {
+						return nil, nil, err
+					}
+					if loadBalancer.LoadBalancerType != nil && loadBalancer.LoadBalancerType.ID == loadBalancerType.ID {
+						return nil, nil, fmt.Errorf("load balancer %d already has type %s", loadBalancer.ID, loadBalancerType.Name)
+					}
+					if err := checkLoadBalancerLimits(loadBalancerType, len(loadBalancer.Services), len(loadBalancer.Targets)); err != nil {
+						return nil, nil, err
+					}
+					var warnings []string
+					if loadBalancerType.Deprecated != nil {
+						warnings = append(warnings, fmt.Sprintf("load balancer type %s is deprecated", loadBalancerType.Name))
+					}
+					loadBalancer.LoadBalancerType = loadBalancerType
+					return loadBalancer, warnings, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).LoadBalancer.ChangeType(ctx, loadBalancer, hcloud.LoadBalancerChangeTypeOpts{
+						LoadBalancerType: loadBalancerType,
+					})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "attach_load_balancer_to_network",
+		Description: "Attaches a Load Balancer to a Network. The network needs a subnet in the network zone of the load balancer.",
+		Handler: func(ctx context.Context, args LoadBalancerNetworkArgs) (*mcpgolang.ToolResponse, error) {
+			opts := hcloud.LoadBalancerAttachToNetworkOpts{}
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "attach_to_network", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					network, err := getNetwork(ctx, args.Network)
+					if err != nil {
+						return nil, nil, err
+					}
+					if err := checkScope("network", network.ID, network.Labels); err != nil {
+						return nil, nil, err
+					}
+					if slices.Contains(loadBalancerNetworks(loadBalancer), network.ID) {
+						return nil, nil, fmt.Errorf("load balancer %d is already attached to network %d", loadBalancer.ID, network.ID)
+					}
+					var networkZone hcloud.NetworkZone
+					if loadBalancer.Location != nil {
+						networkZone = loadBalancer.Location.NetworkZone
+					}
+					subnets := zoneSubnets(network, networkZone)
+					if len(subnets) == 0 {
+						return nil, nil, fmt.Errorf("network %d has no subnet in the network zone %s of load balancer %d", network.ID, networkZone, loadBalancer.ID)
+					}
+					opts.Network = network
+					if args.IP != EmptyString {
+						opts.IP, err = parseSubnetIP("load balancer IP", args.IP, network, networkZone, subnets)
+						if err != nil {
+							return nil, nil, err
+						}
+					}
+					loadBalancer.PrivateNet = append(slices.Clone(loadBalancer.PrivateNet), hcloud.LoadBalancerPrivateNet{Network: network, IP: opts.IP})
+					return loadBalancer, nil, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).LoadBalancer.AttachToNetwork(ctx, loadBalancer, opts)
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "detach_load_balancer_from_network",
+		Description: "Detaches a Load Balancer from a Network. Targets using private IPs of the network become unreachable.",
+		Handler: func(ctx context.Context, args LoadBalancerNetworkArgs) (*mcpgolang.ToolResponse, error) {
+			var network *hcloud.Network
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "detach_from_network", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					if args.IP != EmptyString {
+						return nil, nil, fmt.Errorf("ip is only supported when attaching to a network")
+					}
+					var err error
+					network, err = getNetwork(ctx, args.Network)
+					if err != nil {
+						return nil, nil, err
+					}
+					i := slices.IndexFunc(loadBalancer.PrivateNet, func(p hcloud.LoadBalancerPrivateNet) bool {
+						return p.Network != nil && p.Network.ID == network.ID
+					})
+					if i < 0 {
+						return nil, nil, fmt.Errorf("load balancer %d is not attached to network %d", loadBalancer.ID, network.ID)
+					}
+					if !loadBalancer.PublicNet.Enabled && len(loadBalancer.PrivateNet) == 1 {
+						return nil, nil, fmt.Errorf("load balancer %d has no public interface, enable it before detaching the last network", loadBalancer.ID)
+					}
+					loadBalancer.PrivateNet = slices.Delete(slices.Clone(loadBalancer.PrivateNet), i, i+1)
+					var warnings []string
+					if slices.ContainsFunc(loadBalancer.Targets, func(t hcloud.LoadBalancerTarget) bool { return t.UsePrivateIP }) {
+						warnings = append(warnings, fmt.Sprintf("targets of load balancer %d using private IPs may become unreachable", loadBalancer.ID))
+					}
+					return loadBalancer, warnings, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).LoadBalancer.DetachFromNetwork(ctx, loadBalancer, hcloud.LoadBalancerDetachFromNetworkOpts{Network: network})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "enable_load_balancer_public_interface",
+		Description: "Enables the public interface of a Load Balancer so its services are reachable from the internet.",
+		Handler: func(ctx context.Context, args LoadBalancerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "enable_public_interface", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					var warnings []string
+					if loadBalancer.PublicNet.Enabled {
+						warnings = append(warnings, fmt.Sprintf("public interface of load balancer %d is already enabled", loadBalancer.ID))
+					}
+					loadBalancer.PublicNet.Enabled = true
+					return loadBalancer, warnings, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).LoadBalancer.EnablePublicInterface(ctx, loadBalancer)
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "disable_load_balancer_public_interface",
+		Description: "Disables the public interface of a Load Balancer. Its services are then only reachable from its networks.",
+		Handler: func(ctx context.Context, args LoadBalancerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, loadBalancerTarget, args.ID, args.WriteArgs, "disable_public_interface", false,
+				func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.LoadBalancer, []string, error) {
+					if len(loadBalancer.PrivateNet) == 0 {
+						return nil, nil, fmt.Errorf("load balancer %d is not attached to a network, it would be unreachable", loadBalancer.ID)
+					}
+					var warnings []string
+					if !loadBalancer.PublicNet.Enabled {
+						warnings = append(warnings, fmt.Sprintf("public interface of load balancer %d is already disabled", loadBalancer.ID))
+					}
+					loadBalancer.PublicNet.Enabled = false
+					return loadBalancer, warnings, nil
+				},
+				singleAction(func(ctx context.Context, loadBalancer *hcloud.LoadBalancer) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).LoadBalancer.DisablePublicInterface(ctx, loadBalancer)
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
 }
@@ -11,6 +11,46 @@ import (
 // It contains the LoadBalancerType ID or Name that is needed to perform the lookup.
 type LoadBalancerTypeReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The Load Balancer Type id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+type LoadBalancerTypeResponse struct {
+	ID                      int64                     `json:"id" jsonschema:"required,description=Unique identifier of the load balancer type"`
+	Name                    string                    `json:"name" jsonschema:"required,description=The name of the load balancer type (e.g. lb11)"`
+	Description             string                    `json:"description" jsonschema:"description=The description of the load balancer type"`
+	MaxConnections          int                       `json:"max_connections" jsonschema:"required,description=Maximum number of simultaneous open connections"`
+	MaxServices             int                       `json:"max_services" jsonschema:"required,description=Maximum number of services"`
+	MaxTargets              int                       `json:"max_targets" jsonschema:"required,description=Maximum number of targets"`
+	MaxAssignedCertificates int                       `json:"max_assigned_certificates" jsonschema:"required,description=Maximum number of certificates assigned to services"`
+	Prices                  []LocationPricingResponse `json:"prices" jsonschema:"description=Prices of the load balancer type per location"`
+	Deprecated              *string                   `json:"deprecated,omitempty" jsonschema:"description=Timestamp of when the load balancer type was deprecated"`
+}
+
+func toLoadBalancerTypeResponse(l *hcloud.LoadBalancerType) *LoadBalancerTypeResponse {
+	if l == nil {
+		return nil
+	}
+
+	return &LoadBalancerTypeResponse{
+		ID:                      l.ID,
+		Name:                    l.Name,
+		Description:             l.Description,
+		MaxConnections:          l.MaxConnections,
+		MaxServices:             l.MaxServices,
+		MaxTargets:              l.MaxTargets,
+		MaxAssignedCertificates: l.MaxAssignedCertificates,
+		Prices:                  toLoadBalancerTypePrices(l.Pricings),
+		Deprecated:              l.Deprecated,
+	}
+}
+
+func toLoadBalancerTypePrices(pricings []hcloud.LoadBalancerTypeLocationPricing) []LocationPricingResponse {
+	prices := make([]LocationPricingResponse, 0, len(pricings))
+	for _, p := range pricings {
+		prices = append(prices, toLocationPricingResponse(p.Location, p.Hourly, p.Monthly, p.IncludedTraffic, p.PerTBTraffic))
+	}
+	return prices
 }
 
 // LoadBalancerTypeTools
@@ -18,10 +58,13 @@ var loadBalancerTypeTools = []Tool{
 	{
 		Name:        "get_all_load_balancer_types",
 		Description: "Returns all LoadBalancerTypes objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.LoadBalancerType, error) {
-				result, err := client.LoadBalancerType.All(context.Background())
-				return result, err
+		Handler: func(ctx context.Context, _ NoArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() ([]*LoadBalancerTypeResponse, error) {
+				result, err := hcloudClient(ctx).LoadBalancerType.All(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return toResponseList(result, toLoadBalancerTypeResponse), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -29,10 +72,13 @@ var loadBalancerTypeTools = []Tool{
 	{
 		Name:        "get_a_load_balancer_type_by_id_or_name",
 		Description: "Retrieves a LoadBalancerType by its ID or Name. Get retrieves a load balancer type by its ID if the input can be parsed as an integer, otherwise it retrieves a load balancer type by its name. If the load balancer type does not exist, nil is returned.",
-		Handler: func(args LoadBalancerTypeReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.LoadBalancerType, error) {
-				result, _, err := client.LoadBalancerType.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args LoadBalancerTypeReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*LoadBalancerTypeResponse, error) {
+				result, _, err := hcloudClient(ctx).LoadBalancerType.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				return toLoadBalancerTypeResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -11,6 +11,43 @@ import (
 // It contains the Location ID or Name that is needed to perform the lookup.
 type LocationReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The location id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+type LocationResponse struct {
+	ID          int64   `json:"id" jsonschema:"required,description=Unique identifier of the location"`
+	Name        string  `json:"name" jsonschema:"required,description=The name of the location (e.g. fsn1)"`
+	Description string  `json:"description" jsonschema:"description=The description of the location"`
+	Country     string  `json:"country" jsonschema:"description=ISO 3166-1 alpha-2 code of the country the location is in"`
+	City        string  `json:"city" jsonschema:"description=The city the location is closest to"`
+	Latitude    float64 `json:"latitude" jsonschema:"description=Latitude of the city"`
+	Longitude   float64 `json:"longitude" jsonschema:"description=Longitude of the city"`
+	NetworkZone string  `json:"network_zone" jsonschema:"description=The network zone the location is part of"`
+}
+
+func toLocationResponse(l *hcloud.Location) *LocationResponse {
+	if l == nil {
+		return nil
+	}
+
+	return &LocationResponse{
+		ID:          l.ID,
+		Name:        l.Name,
+		Description: l.Description,
+		Country:     l.Country,
+		City:        l.City,
+		Latitude:    l.Latitude,
+		Longitude:   l.Longitude,
+		NetworkZone: string(l.NetworkZone),
+	}
+}
+
+func toLocationRef(l *hcloud.Location) *ResourceRef {
+	if l == nil {
+		return nil
+	}
+	return toResourceRef(l.ID, l.Name)
 }
 
 // LocationTools
@@ -18,10 +55,17 @@ var locationTools = []Tool{
 	{
 		Name:        "get_all_locations",
 		Description: "Returns all Locations objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.Location, error) {
-				result, err := client.Location.All(context.Background())
-				return result, err
+		Handler: func(ctx context.Context, _ NoArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() ([]*LocationResponse, error) {
+				result, err := hcloudClient(ctx).Location.All(ctx)
+				if err != nil {
+					return nil, err
+				}
+				locations := make([]*LocationResponse, 0, len(result))
+				for _, l := range result {
+					locations = append(locations, toLocationResponse(l))
+				}
+				return locations, nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -29,10 +73,13 @@ var locationTools = []Tool{
 	{
 		Name:        "get_a_location_by_id_or_name",
 		Description: "Retrieves a Location by its ID or Name, Get retrieves a Location by its ID if the input can be parsed as an integer, otherwise it retrieves a Location by its name. If the Location does not exist, nil is returned.",
-		Handler: func(args LocationReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.Location, error) {
-				result, _, err := client.Location.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args LocationReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*LocationResponse, error) {
+				result, _, err := hcloudClient(ctx).Location.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				return toLocationResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -2,69 +2,37 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"log"
+	"net/http"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
-	"github.com/joho/godotenv"
 	mcpgolang "github.com/metoro-io/mcp-golang"
-	"github.com/metoro-io/mcp-golang/transport/stdio"
 )
 
-var client *hcloud.Client
-
 // Generalized response handler for listing and getting server/location info
-func handleResponse[T any](fetchFunc func() (T, error)) (*mcpgolang.ToolResponse, error) {
+func handleResponse[T any](ctx context.Context, fetchFunc func() (T, error)) (*mcpgolang.ToolResponse, error) {
 	// Fetch data using the provided fetch function
 	data, err := fetchFunc()
 	if err != nil {
 		return nil, err
 	}
 
-	// Marshal the data into the desired format
-	marshaledData, err := json.MarshalIndent(data, "", "  ")
+	// Marshal the data into the requested format
+	formatted, err := formatResponse(data, outputFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal data: %w", err)
 	}
 
 	// Return the response
-	return mcpgolang.NewToolResponse(mcpgolang.NewTextContent(string(marshaledData))), nil
-}
-
-// LoadToken loads the Hetzner Cloud API token from the command-line flag, environment variable, or .env file
-func loadToken() string {
-	// Define command-line flag
-	tokenFlag := flag.String("token", "", "Hetzner Cloud API token")
-	flag.Parse()
-
-	// Try to load .env file from executable directory
-	cwd, err := os.Getwd()
-	if err == nil {
-		envPath := filepath.Join(cwd, ".env")
-		_ = godotenv.Load(envPath) // ignore error
-	}
-
-	// Get token from command-line flag
-	hcloudToken := *tokenFlag
-
-	// If flag not provided, check environment variable
-	if hcloudToken == EmptyString {
-		hcloudToken = os.Getenv("HCLOUD_TOKEN")
-	}
-
-	// If still empty, show error and help
-	if hcloudToken == EmptyString {
-		fmt.Println("Error: HCLOUD_TOKEN is not set.\n" +
-			"You can provide the token using one of the following methods:\n" +
-			"1. Command line: ./mcp-hetzner -token=your_token_here\n" +
-			"2. Environment var: export HCLOUD_TOKEN=your_token_here && ./mcp-hetzner\n" +
-			"3. .env file: create a .env file with HCLOUD_TOKEN=your_token_here")
-	}
-
-	return hcloudToken
+	return mcpgolang.NewToolResponse(mcpgolang.NewTextContent(truncateResponse(formatted))), nil
 }
 
 // Is Allowed
@@ -79,8 +47,10 @@ func isAllowed(toolRestriction, globalRestriction Restriction) bool {
 }
 
 // Allowed Tools
-func collectAllowedTools(restriction Restriction) []Tool {
+func collectAllowedTools(restriction Restriction, filter ToolFilter) []Tool {
 	toolGroups := [][]Tool{
+		projectTools,
+		actionTools,
 		certificateTools,
 		locationTools,
 		datacenterTools,
@@ -92,19 +62,21 @@ func collectAllowedTools(restriction Restriction) []Tool {
 		isoTools,
 		placementGroupTools,
 		primaryIPTools,
+		ipTools,
 		serverTypeTools,
 		loadBalancerTools,
 		loadBalancerTypeTools,
 		networkTools,
 		volumeTools,
 		priceTools,
+		snapshotPolicyTools,
 	}
 
 	var allowed []Tool
 
 	for _, group := range toolGroups {
 		for _, tool := range group {
-			if isAllowed(tool.Restriction, restriction) {
+			if isAllowed(tool.Restriction, restriction) && filter.Allows(tool.Name) {
 				allowed = append(allowed, tool)
 			}
 		}
@@ -114,56 +86,127 @@ func collectAllowedTools(restriction Restriction) []Tool {
 }
 
 // Register Tools
-func registerTools(server *mcpgolang.Server, restriction Restriction) error {
-	allTools := collectAllowedTools(restriction)
+func registerTools(server *mcpgolang.Server, restriction Restriction, filter ToolFilter) error {
+	allTools := collectAllowedTools(restriction, filter)
 
+	names := make([]string, 0, len(allTools))
 	for _, tool := range allTools {
-		if err := server.RegisterTool(tool.Name, tool.Description, tool.Handler); err != nil {
+		if err := server.RegisterTool(tool.Name, tool.Description, wrapToolHandler(tool, restriction)); err != nil {
 			return fmt.Errorf("failed to register tool %s: %w", tool.Name, err)
 		}
+		names = append(names, tool.Name)
 	}
 
+	log.Printf("Registered %d tools: %s", len(names), strings.Join(names, ", "))
+
 	return nil
 }
 
 // Start
 func main() {
+	if err := run(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run starts the server with the given command-line arguments and serves until SIGINT or SIGTERM.
+func run(arguments []string) error {
 	done := make(chan struct{})
 
-	// Define CLI flag for restriction
-	restrictionFlag := flag.String("restriction", string(RestrictionReadOnly), "Restriction for the tool")
-	flag.Parse()
+	// Load configuration from flags, environment, .env and configuration file
+	config, err := loadConfig(arguments)
+	if err != nil {
+		return err
+	}
+
+	dryRunMode = config.DryRun
+	maxResponseSize = config.MaxResponseSize
+	actionWaitTimeout = config.ActionTimeout
+	restriction := config.Restriction
+	filter := ToolFilter{Allow: config.AllowTools, Deny: config.DenyTools}
 
-	// Validate restriction flag
-	var restriction Restriction
-	if *restrictionFlag == string(RestrictionReadOnly) {
-		restriction = RestrictionReadOnly
-	} else if *restrictionFlag == string(RestrictionReadWrite) {
-		restriction = RestrictionReadWrite
-	} else {
-		panic("invalid restriction")
+	if config.ScopeLabelSelector != EmptyString {
+		scope, err = newLabelScope(config.ScopeLabelSelector, config.ScopeFilterLists)
+		if err != nil {
+			return err
+		}
 	}
 
-	// New Stdio Server
-	server := mcpgolang.NewServer(stdio.NewStdioServerTransport())
+	// Open audit log
+	if config.AuditLog != EmptyString {
+		auditLog, err = newAuditLog(config.AuditLog, config.AuditMaxSize, config.AuditMaxBackups)
+		if err != nil {
+			return err
+		}
+		defer auditLog.Close()
+	}
 
-	// Load Hetzner Cloud token
-	hcloudToken := loadToken()
+	// Hetzner Cloud projects, a single project from the token if none are configured
+	projectConfigs := config.Projects
+	if len(projectConfigs) == 0 {
+		token, err := config.token()
+		if err != nil {
+			return err
+		}
+		projectConfigs = []ProjectConfig{{Name: DefaultProjectName, Token: token}}
+	}
+	clientOpts := []hcloud.ClientOption{hcloud.WithHTTPClient(&http.Client{Timeout: config.RequestTimeout})}
+	if config.Endpoint != EmptyString {
+		clientOpts = append(clientOpts, hcloud.WithEndpoint(config.Endpoint))
+	}
+	projects, defaultProject, err = newProjects(projectConfigs, config.DefaultProject, restriction, clientOpts...)
+	if err != nil {
+		return err
+	}
+	snapshotPolicies = config.SnapshotPolicies
 
-	// Hetzner Cloud Client
-	client = hcloud.NewClient(hcloud.WithToken(hcloudToken))
+	// New Server
+	authenticator, err := newAuthenticator(config.AuthFile, config.TLSCert, config.TLSKey, config.TLSClientCA)
+	if err != nil {
+		return err
+	}
+	if config.Transport != TransportStdio && !authenticator.Enabled() {
+		log.Printf("WARNING: the %s transport accepts requests without authentication, use --auth-file or --tls-client-ca", config.Transport)
+	}
+	serverTransport, err := newTransport(config.Transport, config.Listen, authenticator)
+	if err != nil {
+		return err
+	}
+	server := mcpgolang.NewServer(serverTransport)
 
 	// Register Tool
-	err := registerTools(server, restriction)
+	err = registerTools(server, restriction, filter)
 	if err != nil {
-		panic(err)
+		return err
 	}
 
 	// Run server
 	err = server.Serve()
 	if err != nil {
-		panic(err)
+		return err
 	}
 
+	// Shut down gracefully on SIGINT or SIGTERM
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if config.Transport != TransportStdio {
+		// Long-lived servers run snapshot policies on their schedule
+		go runSnapshotScheduler(ctx, restriction, filter)
+	}
+	go func() {
+		<-ctx.Done()
+		log.Printf("Shutting down")
+		if err := serverTransport.Close(); err != nil {
+			log.Printf("Failed to shut down: %v", err)
+		}
+		close(done)
+	}()
+
 	<-done
+
+	return nil
 }
@@ -2,6 +2,11 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	mcpgolang "github.com/metoro-io/mcp-golang"
@@ -11,17 +16,467 @@ import (
 // It contains the Network ID or Name that is needed to perform the lookup.
 type NetworkReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The network id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// NetworkListArgs represents the arguments for listing Networks.
+// Networks can be filtered by name on top of the label selector.
+type NetworkListArgs struct {
+	Name string `json:"name,omitempty" jsonschema:"description=Only return the network with this name"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// NetworkSubnetArgs represents a subnet of a Network.
+type NetworkSubnetArgs struct {
+	Type        string `json:"type" jsonschema:"required,description=Type of the subnet (cloud/server/vswitch)"`
+	IPRange     string `json:"ip_range" jsonschema:"required,description=IP range of the subnet in CIDR notation (e.g. 10.0.1.0/24). Must be inside the IP range of the network"`
+	NetworkZone string `json:"network_zone" jsonschema:"required,description=Network zone of the subnet (e.g. eu-central)"`
+	VSwitchID   int64  `json:"vswitch_id,omitempty" jsonschema:"description=ID of the robot vSwitch. Required for vswitch subnets"`
+}
+
+// NetworkRouteArgs represents a route of a Network.
+type NetworkRouteArgs struct {
+	Destination string `json:"destination" jsonschema:"required,description=Destination network of the route in CIDR notation (e.g. 10.100.1.0/24)"`
+	Gateway     string `json:"gateway" jsonschema:"required,description=Gateway of the route. Must be inside the IP range of the network"`
+}
+
+// NetworkCreateArgs contains the necessary fields to create a new network,
+// including its IP range and optionally its subnets and routes.
+type NetworkCreateArgs struct {
+	Name                  string              `json:"name" jsonschema:"required,description=The network name"`
+	IPRange               string              `json:"ip_range" jsonschema:"required,description=IP range of the network in CIDR notation (e.g. 10.0.0.0/16). Must be a private RFC 1918 range"`
+	Subnets               []NetworkSubnetArgs `json:"subnets,omitempty" jsonschema:"description=Subnets of the network"`
+	Routes                []NetworkRouteArgs  `json:"routes,omitempty" jsonschema:"description=Routes of the network"`
+	ExposeRoutesToVSwitch bool                `json:"expose_routes_to_vswitch,omitempty" jsonschema:"description=Whether the routes are exposed to the robot vSwitch"`
+	Labels                map[string]string   `json:"labels,omitempty" jsonschema:"description=User-defined labels for the network"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// NetworkActionArgs represents the arguments required to run an action on a Network.
+// It contains the Network ID the action is performed on.
+type NetworkActionArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The network id"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// NetworkUpdateArgs represents the arguments required to update a Network.
+type NetworkUpdateArgs struct {
+	ID                    int64             `json:"id" jsonschema:"required,description=The network id"`
+	Name                  string            `json:"name,omitempty" jsonschema:"description=New name of the network"`
+	Labels                map[string]string `json:"labels,omitempty" jsonschema:"description=New labels of the network. Replaces all existing labels"`
+	ExposeRoutesToVSwitch *bool             `json:"expose_routes_to_vswitch,omitempty" jsonschema:"description=Whether the routes are exposed to the robot vSwitch"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// NetworkSubnetAddArgs represents the arguments required to add a subnet to a Network.
+type NetworkSubnetAddArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The network id"`
+	NetworkSubnetArgs
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// NetworkSubnetDeleteArgs represents the arguments required to delete a subnet from a Network.
+type NetworkSubnetDeleteArgs struct {
+	ID      int64  `json:"id" jsonschema:"required,description=The network id"`
+	IPRange string `json:"ip_range" jsonschema:"required,description=IP range of the subnet to delete in CIDR notation"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// NetworkRouteChangeArgs represents the arguments required to add a route to or delete a route from a Network.
+type NetworkRouteChangeArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The network id"`
+	NetworkRouteArgs
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// NetworkIPRangeArgs represents the arguments required to change the IP range of a Network.
+type NetworkIPRangeArgs struct {
+	ID      int64  `json:"id" jsonschema:"required,description=The network id"`
+	IPRange string `json:"ip_range" jsonschema:"required,description=New IP range of the network in CIDR notation. It can only be extended"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// NetworkProtectionArgs represents the arguments required to change the protection of a Network.
+type NetworkProtectionArgs struct {
+	ID     int64 `json:"id" jsonschema:"required,description=The network id"`
+	Delete bool  `json:"delete" jsonschema:"required,description=Whether the network is protected from deletion"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+type NetworkSubnetResponse struct {
+	Type        string `json:"type" jsonschema:"required,description=Type of the subnet (cloud/server/vswitch)"`
+	IPRange     string `json:"ip_range" jsonschema:"required,description=IP range of the subnet in CIDR notation"`
+	NetworkZone string `json:"network_zone" jsonschema:"required,description=Network zone of the subnet (e.g. eu-central)"`
+	Gateway     string `json:"gateway" jsonschema:"description=Gateway of the subnet"`
+	VSwitchID   int64  `json:"vswitch_id,omitempty" jsonschema:"description=ID of the robot vSwitch for vswitch subnets"`
+}
+
+type NetworkRouteResponse struct {
+	Destination string `json:"destination" jsonschema:"required,description=Destination network of the route in CIDR notation"`
+	Gateway     string `json:"gateway" jsonschema:"required,description=Gateway of the route"`
+}
+
+type NetworkResponse struct {
+	ID                    int64                   `json:"id" jsonschema:"required,description=Unique identifier of the network"`
+	Name                  string                  `json:"name" jsonschema:"required,description=The name of the network"`
+	IPRange               string                  `json:"ip_range" jsonschema:"required,description=IP range of the network in CIDR notation"`
+	Subnets               []NetworkSubnetResponse `json:"subnets" jsonschema:"description=Subnets of the network"`
+	Routes                []NetworkRouteResponse  `json:"routes" jsonschema:"description=Routes of the network"`
+	Servers               []*ResourceRef          `json:"servers" jsonschema:"description=Servers attached to the network"`
+	LoadBalancers         []*ResourceRef          `json:"load_balancers" jsonschema:"description=Load balancers attached to the network"`
+	ExposeRoutesToVSwitch bool                    `json:"expose_routes_to_vswitch" jsonschema:"description=Whether the routes are exposed to the robot vSwitch"`
+	Protection            DeleteProtection        `json:"protection" jsonschema:"required,description=Protection settings of the network"`
+	Labels                map[string]string       `json:"labels" jsonschema:"description=User-defined labels for the network"`
+	Created               time.Time               `json:"created" jsonschema:"required,description=Timestamp of when the network was created"`
+}
+
+func toNetworkResponse(n *hcloud.Network) *NetworkResponse {
+	if n == nil {
+		return nil
+	}
+
+	subnets := make([]NetworkSubnetResponse, 0, len(n.Subnets))
+	for _, s := range n.Subnets {
+		subnets = append(subnets, NetworkSubnetResponse{
+			Type:        string(s.Type),
+			IPRange:     ipNetString(s.IPRange),
+			NetworkZone: string(s.NetworkZone),
+			Gateway:     ipString(s.Gateway),
+			VSwitchID:   s.VSwitchID,
+		})
+	}
+
+	routes := make([]NetworkRouteResponse, 0, len(n.Routes))
+	for _, r := range n.Routes {
+		routes = append(routes, NetworkRouteResponse{
+			Destination: ipNetString(r.Destination),
+			Gateway:     ipString(r.Gateway),
+		})
+	}
+
+	servers := make([]*ResourceRef, 0, len(n.Servers))
+	for _, s := range n.Servers {
+		if s != nil {
+			servers = append(servers, toServerRef(s))
+		}
+	}
+
+	loadBalancers := make([]*ResourceRef, 0, len(n.LoadBalancers))
+	for _, l := range n.LoadBalancers {
+		if l != nil {
+			loadBalancers = append(loadBalancers, toResourceRef(l.ID, l.Name))
+		}
+	}
+
+	return &NetworkResponse{
+		ID:                    n.ID,
+		Name:                  n.Name,
+		IPRange:               ipNetString(n.IPRange),
+		Subnets:               subnets,
+		Routes:                routes,
+		Servers:               servers,
+		LoadBalancers:         loadBalancers,
+		ExposeRoutesToVSwitch: n.ExposeRoutesToVSwitch,
+		Protection:            DeleteProtection{Delete: n.Protection.Delete},
+		Labels:                n.Labels,
+		Created:               n.Created,
+	}
+}
+
+type NetworkActionResponse struct {
+	Network *NetworkResponse `json:"network" jsonschema:"description=The network the action was performed on; null if it was deleted"`
+	Action  *ActionResponse  `json:"action" jsonschema:"description=The triggered action; null if the change did not need one"`
+}
+
+// privateIPRanges are the RFC 1918 ranges networks can use.
+var privateIPRanges = []*net.IPNet{
+	{IP: net.IPv4(10, 0, 0, 0).To4(), Mask: net.CIDRMask(8, 32)},
+	{IP: net.IPv4(172, 16, 0, 0).To4(), Mask: net.CIDRMask(12, 32)},
+	{IP: net.IPv4(192, 168, 0, 0).To4(), Mask: net.CIDRMask(16, 32)},
+}
+
+// parseIPRange parses an IP range in CIDR notation. The address must be the first address of the range.
+func parseIPRange(name, value string) (*net.IPNet, error) {
+	ip, ipRange, err := net.ParseCIDR(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q, must be in CIDR notation (e.g. 10.0.0.0/16)", name, value)
+	}
+	if !ip.Equal(ipRange.IP) {
+		return nil, fmt.Errorf("invalid %s %q, %s is not the first address of the range, use %s", name, value, ip, ipRange)
+	}
+	return ipRange, nil
+}
+
+// parseIP parses a single IP address.
+func parseIP(name, value string) (net.IP, error) {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, fmt.Errorf("invalid %s %q, must be an IP address (e.g. 10.0.1.5)", name, value)
+	}
+	return ip, nil
+}
+
+// containsIPRange reports whether the outer IP range contains the whole inner IP range.
+func containsIPRange(outer, inner *net.IPNet) bool {
+	outerOnes, outerBits := outer.Mask.Size()
+	innerOnes, innerBits := inner.Mask.Size()
+	return outerBits == innerBits && innerOnes >= outerOnes && outer.Contains(inner.IP)
+}
+
+// overlapIPRanges reports whether the IP ranges have addresses in common.
+func overlapIPRanges(a, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+// checkNetworkIPRange fails if the IP range of a network is not a private IPv4 range.
+func checkNetworkIPRange(ipRange *net.IPNet) error {
+	for _, private := range privateIPRanges {
+		if containsIPRange(private, ipRange) {
+			return nil
+		}
+	}
+	return fmt.Errorf("IP range %s is not inside one of the private ranges 10.0.0.0/8, 172.16.0.0/12 or 192.168.0.0/16", ipRange)
+}
+
+// toNetworkSubnet validates a subnet against the IP range of its network and the existing subnets.
+func toNetworkSubnet(ipRange *net.IPNet, subnets []hcloud.NetworkSubnet, args NetworkSubnetArgs) (hcloud.NetworkSubnet, error) {
+	subnet := hcloud.NetworkSubnet{
+		Type:        hcloud.NetworkSubnetType(args.Type),
+		NetworkZone: hcloud.NetworkZone(args.NetworkZone),
+		VSwitchID:   args.VSwitchID,
+	}
+	switch subnet.Type {
+	case hcloud.NetworkSubnetTypeCloud, hcloud.NetworkSubnetTypeServer:
+		if args.VSwitchID != 0 {
+			return subnet, fmt.Errorf("vswitch_id is only supported for vswitch subnets")
+		}
+	case hcloud.NetworkSubnetTypeVSwitch:
+		if args.VSwitchID == 0 {
+			return subnet, fmt.Errorf("vswitch subnets require a vswitch_id")
+		}
+	default:
+		return subnet, fmt.Errorf("invalid subnet type %q, must be one of cloud, server, vswitch", args.Type)
+	}
+	if args.NetworkZone == EmptyString {
+		return subnet, fmt.Errorf("missing network zone of subnet %s", args.IPRange)
+	}
+
+	var err error
+	subnet.IPRange, err = parseIPRange("subnet IP range", args.IPRange)
+	if err != nil {
+		return subnet, err
+	}
+	if !containsIPRange(ipRange, subnet.IPRange) {
+		return subnet, fmt.Errorf("subnet %s is not inside the IP range %s of the network", subnet.IPRange, ipRange)
+	}
+	for _, existing := range subnets {
+		if overlapIPRanges(existing.IPRange, subnet.IPRange) {
+			return subnet, fmt.Errorf("subnet %s overlaps with subnet %s", subnet.IPRange, existing.IPRange)
+		}
+	}
+	return subnet, nil
+}
+
+// toNetworkRoute validates a route against the IP range of its network.
+func toNetworkRoute(ipRange *net.IPNet, args NetworkRouteArgs) (hcloud.NetworkRoute, error) {
+	var route hcloud.NetworkRoute
+	var err error
+	route.Destination, err = parseIPRange("route destination", args.Destination)
+	if err != nil {
+		return route, err
+	}
+	route.Gateway, err = parseIP("route gateway", args.Gateway)
+	if err != nil {
+		return route, err
+	}
+	if !ipRange.Contains(route.Gateway) {
+		return route, fmt.Errorf("gateway %s is not inside the IP range %s of the network", route.Gateway, ipRange)
+	}
+	return route, nil
+}
+
+// zoneSubnets returns the IP ranges of the subnets of a network in the network zone
+// which servers and load balancers can be attached to.
+func zoneSubnets(network *hcloud.Network, networkZone hcloud.NetworkZone) []*net.IPNet {
+	var subnets []*net.IPNet
+	for _, subnet := range network.Subnets {
+		if subnet.Type != hcloud.NetworkSubnetTypeVSwitch && subnet.NetworkZone == networkZone {
+			subnets = append(subnets, subnet.IPRange)
+		}
+	}
+	return subnets
+}
+
+// parseSubnetIP parses an IP address a resource should have in a network.
+// It must be inside one of the given subnets of the network zone.
+func parseSubnetIP(name, value string, network *hcloud.Network, networkZone hcloud.NetworkZone, subnets []*net.IPNet) (net.IP, error) {
+	ip, err := parseIP(name, value)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(subnets, func(subnet *net.IPNet) bool { return subnet.Contains(ip) }) {
+		return nil, fmt.Errorf("%s %s is not inside a subnet of network %d in the network zone %s", name, ip, network.ID, networkZone)
+	}
+	return ip, nil
+}
+
+func toNetworkCreateOpts(args NetworkCreateArgs) (hcloud.NetworkCreateOpts, error) {
+	opts := hcloud.NetworkCreateOpts{
+		Name:                  args.Name,
+		ExposeRoutesToVSwitch: args.ExposeRoutesToVSwitch,
+	}
+	labels, err := scopeLabels(args.Labels)
+	if err != nil {
+		return opts, err
+	}
+	opts.Labels = labels
+
+	opts.IPRange, err = parseIPRange("network IP range", args.IPRange)
+	if err != nil {
+		return opts, err
+	}
+	if err := checkNetworkIPRange(opts.IPRange); err != nil {
+		return opts, err
+	}
+	for _, subnetArgs := range args.Subnets {
+		subnet, err := toNetworkSubnet(opts.IPRange, opts.Subnets, subnetArgs)
+		if err != nil {
+			return opts, err
+		}
+		opts.Subnets = append(opts.Subnets, subnet)
+	}
+	for _, routeArgs := range args.Routes {
+		route, err := toNetworkRoute(opts.IPRange, routeArgs)
+		if err != nil {
+			return opts, err
+		}
+		opts.Routes = append(opts.Routes, route)
+	}
+
+	return opts, opts.Validate()
+}
+
+// planNetworkCreate validates the options for creating a Network and returns the planned change.
+func planNetworkCreate(ctx context.Context, opts hcloud.NetworkCreateOpts) (*PlannedChange, error) {
+	existing, _, err := hcloudClient(ctx).Network.GetByName(ctx, opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("network %s already exists", opts.Name)
+	}
+
+	return newPlannedChange("create_network", toNetworkResponse(&hcloud.Network{
+		Name:                  opts.Name,
+		IPRange:               opts.IPRange,
+		Subnets:               opts.Subnets,
+		Routes:                opts.Routes,
+		ExposeRoutesToVSwitch: opts.ExposeRoutesToVSwitch,
+		Labels:                opts.Labels,
+		Created:               time.Now(),
+	})), nil
+}
+
+// getNetwork retrieves a Network by its ID or name and fails if the Network does not exist.
+func getNetwork(ctx context.Context, idOrName string) (*hcloud.Network, error) {
+	network, _, err := hcloudClient(ctx).Network.Get(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if network == nil {
+		return nil, fmt.Errorf("network %s not found", idOrName)
+	}
+	return network, nil
+}
+
+// subnetAttachments returns the Servers and Load Balancers with a private IP inside the subnet, e.g. "server 2 (10.0.1.2)".
+// Networks only reference them by ID, so every attached resource is retrieved.
+func subnetAttachments(ctx context.Context, network *hcloud.Network, ipRange *net.IPNet) ([]string, error) {
+	var attached []string
+	for _, ref := range network.Servers {
+		server, _, err := hcloudClient(ctx).Server.GetByID(ctx, ref.ID)
+		if err != nil {
+			return nil, err
+		}
+		if server == nil {
+			continue
+		}
+		for _, privateNet := range server.PrivateNet {
+			if privateNet.Network != nil && privateNet.Network.ID == network.ID && ipRange.Contains(privateNet.IP) {
+				attached = append(attached, fmt.Sprintf("server %d (%s)", server.ID, privateNet.IP))
+			}
+		}
+	}
+	for _, ref := range network.LoadBalancers {
+		loadBalancer, _, err := hcloudClient(ctx).LoadBalancer.GetByID(ctx, ref.ID)
+		if err != nil {
+			return nil, err
+		}
+		if loadBalancer == nil {
+			continue
+		}
+		for _, privateNet := range loadBalancer.PrivateNet {
+			if privateNet.Network != nil && privateNet.Network.ID == network.ID && ipRange.Contains(privateNet.IP) {
+				attached = append(attached, fmt.Sprintf("load balancer %d (%s)", loadBalancer.ID, privateNet.IP))
+			}
+		}
+	}
+	return attached, nil
+}
+
+// networkTarget runs actions on Networks with runAction.
+var networkTarget = actionTarget[hcloud.Network, hcloud.Network]{
+	name:       "network",
+	actionType: "network",
+	get: func(ctx context.Context, id int64) (*hcloud.Network, error) {
+		return getNetwork(ctx, fmt.Sprint(id))
+	},
+	labels:  func(network *hcloud.Network) map[string]string { return network.Labels },
+	convert: func(network *hcloud.Network) *hcloud.Network { return network },
+	respond: func(network *hcloud.Network, actions []*ActionResponse) any {
+		return &NetworkActionResponse{Network: toNetworkResponse(network), Action: firstAction(actions)}
+	},
 }
 
 // NetworkTools
 var networkTools = []Tool{
 	{
 		Name:        "get_all_networks",
-		Description: "Returns all Networks objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.Network, error) {
-				result, err := client.Network.All(context.Background())
-				return result, err
+		Description: "Returns a page of Networks, optionally filtered by name and label selector.",
+		Handler: func(ctx context.Context, args NetworkListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*NetworkResponse], error) {
+				listOpts, err := args.listOpts()
+				if err != nil {
+					return nil, err
+				}
+				opts := hcloud.NetworkListOpts{
+					ListOpts: listOpts,
+					Name:     args.Name,
+				}
+				result, resp, err := hcloudClient(ctx).Network.List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return newListResponse(toResponseList(result, toNetworkResponse), resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -29,12 +484,259 @@ var networkTools = []Tool{
 	{
 		Name:        "get_a_network_by_id_or_name",
 		Description: "Retrieves a Network by its ID or Name. Get retrieves a network by its ID if the input can be parsed as an integer, otherwise it retrieves a network by its name. If the network does not exist, nil is returned.",
-		Handler: func(args NetworkReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.Network, error) {
-				result, _, err := client.Network.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args NetworkReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*NetworkResponse, error) {
+				result, _, err := hcloudClient(ctx).Network.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return toNetworkResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
 	},
+	{
+		Name:        "create_a_network",
+		Description: "Creates a new private Network with an IP range and optionally subnets and routes. Subnets must be inside the IP range of the network and must not overlap.",
+		Handler: func(ctx context.Context, args NetworkCreateArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				opts, err := toNetworkCreateOpts(args)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					return planNetworkCreate(ctx, opts)
+				}
+				result, _, err := hcloudClient(ctx).Network.Create(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return toNetworkResponse(result), nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "update_a_network",
+		Description: "Renames a Network, replaces its labels or changes whether its routes are exposed to the robot vSwitch.",
+		Handler: func(ctx context.Context, args NetworkUpdateArgs) (*mcpgolang.ToolResponse, error) {
+			opts := hcloud.NetworkUpdateOpts{
+				Name:                  args.Name,
+				ExposeRoutesToVSwitch: args.ExposeRoutesToVSwitch,
+			}
+			return runAction(ctx, networkTarget, args.ID, args.WriteArgs, "update_network", true,
+				func(ctx context.Context, network *hcloud.Network) (*hcloud.Network, []string, error) {
+					if args.Name == EmptyString && args.Labels == nil && args.ExposeRoutesToVSwitch == nil {
+						return nil, nil, fmt.Errorf("either name, labels or expose_routes_to_vswitch is required")
+					}
+					if args.Name != EmptyString {
+						network.Name = args.Name
+					}
+					if args.Labels != nil {
+						labels, err := scopeLabels(args.Labels)
+						if err != nil {
+							return nil, nil, err
+						}
+						opts.Labels = labels
+						network.Labels = labels
+					}
+					if args.ExposeRoutesToVSwitch != nil {
+						network.ExposeRoutesToVSwitch = *args.ExposeRoutesToVSwitch
+					}
+					return network, nil, nil
+				},
+				singleAction(func(ctx context.Context, network *hcloud.Network) (*hcloud.Action, error) {
+					_, _, err := hcloudClient(ctx).Network.Update(ctx, network, opts)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_a_network",
+		Description: "Deletes a Network. Attached servers and load balancers are detached from it. It cannot be undone.",
+		Handler: func(ctx context.Context, args NetworkActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, networkTarget, args.ID, args.WriteArgs, "delete_network", true,
+				func(ctx context.Context, network *hcloud.Network) (*hcloud.Network, []string, error) {
+					if network.Protection.Delete {
+						return nil, nil, fmt.Errorf("network %d is protected against deletion", network.ID)
+					}
+					var warnings []string
+					if len(network.Servers) > 0 || len(network.LoadBalancers) > 0 {
+						warnings = append(warnings, fmt.Sprintf("%d servers and %d load balancers will be detached from network %d", len(network.Servers), len(network.LoadBalancers), network.ID))
+					}
+					return nil, warnings, nil
+				},
+				singleAction(func(ctx context.Context, network *hcloud.Network) (*hcloud.Action, error) {
+					_, err := hcloudClient(ctx).Network.Delete(ctx, network)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "add_subnet_to_network",
+		Description: "Adds a subnet to a Network. The subnet must be inside the IP range of the network and must not overlap with other subnets.",
+		Handler: func(ctx context.Context, args NetworkSubnetAddArgs) (*mcpgolang.ToolResponse, error) {
+			var subnet hcloud.NetworkSubnet
+			return runAction(ctx, networkTarget, args.ID, args.WriteArgs, "add_subnet", false,
+				func(ctx context.Context, network *hcloud.Network) (*hcloud.Network, []string, error) {
+					var err error
+					subnet, err = toNetworkSubnet(network.IPRange, network.Subnets, args.NetworkSubnetArgs)
+					if err != nil {
+						return nil, nil, err
+					}
+					network.Subnets = append(slices.Clone(network.Subnets), subnet)
+					return network, nil, nil
+				},
+				singleAction(func(ctx context.Context, network *hcloud.Network) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Network.AddSubnet(ctx, network, hcloud.NetworkAddSubnetOpts{Subnet: subnet})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_subnet_from_network",
+		Description: "Deletes a subnet from a Network. Subnets with attached Servers or Load Balancers are not deleted; detach them from the network first.",
+		Handler: func(ctx context.Context, args NetworkSubnetDeleteArgs) (*mcpgolang.ToolResponse, error) {
+			var subnet hcloud.NetworkSubnet
+			return runAction(ctx, networkTarget, args.ID, args.WriteArgs, "delete_subnet", false,
+				func(ctx context.Context, network *hcloud.Network) (*hcloud.Network, []string, error) {
+					ipRange, err := parseIPRange("subnet IP range", args.IPRange)
+					if err != nil {
+						return nil, nil, err
+					}
+					i := slices.IndexFunc(network.Subnets, func(s hcloud.NetworkSubnet) bool {
+						return s.IPRange.String() == ipRange.String()
+					})
+					if i < 0 {
+						return nil, nil, fmt.Errorf("network %d has no subnet %s", network.ID, ipRange)
+					}
+					subnet = network.Subnets[i]
+					attached, err := subnetAttachments(ctx, network, subnet.IPRange)
+					if err != nil {
+						return nil, nil, err
+					}
+					if len(attached) > 0 {
+						return nil, nil, fmt.Errorf("subnet %s of network %d is still used by %s, detach them from the network first", subnet.IPRange, network.ID, strings.Join(attached, ", "))
+					}
+					network.Subnets = slices.Delete(slices.Clone(network.Subnets), i, i+1)
+					return network, nil, nil
+				},
+				singleAction(func(ctx context.Context, network *hcloud.Network) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Network.DeleteSubnet(ctx, network, hcloud.NetworkDeleteSubnetOpts{Subnet: subnet})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "add_route_to_network",
+		Description: "Adds a route to a Network. The gateway must be inside the IP range of the network.",
+		Handler: func(ctx context.Context, args NetworkRouteChangeArgs) (*mcpgolang.ToolResponse, error) {
+			var route hcloud.NetworkRoute
+			return runAction(ctx, networkTarget, args.ID, args.WriteArgs, "add_route", false,
+				func(ctx context.Context, network *hcloud.Network) (*hcloud.Network, []string, error) {
+					var err error
+					route, err = toNetworkRoute(network.IPRange, args.NetworkRouteArgs)
+					if err != nil {
+						return nil, nil, err
+					}
+					for _, existing := range network.Routes {
+						if existing.Destination.String() == route.Destination.String() {
+							return nil, nil, fmt.Errorf("network %d already has a route to %s", network.ID, route.Destination)
+						}
+					}
+					network.Routes = append(slices.Clone(network.Routes), route)
+					return network, nil, nil
+				},
+				singleAction(func(ctx context.Context, network *hcloud.Network) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Network.AddRoute(ctx, network, hcloud.NetworkAddRouteOpts{Route: route})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_route_from_network",
+		Description: "Deletes a route from a Network.",
+		Handler: func(ctx context.Context, args NetworkRouteChangeArgs) (*mcpgolang.ToolResponse, error) {
+			var route hcloud.NetworkRoute
+			return runAction(ctx, networkTarget, args.ID, args.WriteArgs, "delete_route", false,
+				func(ctx context.Context, network *hcloud.Network) (*hcloud.Network, []string, error) {
+					var err error
+					route, err = toNetworkRoute(network.IPRange, args.NetworkRouteArgs)
+					if err != nil {
+						return nil, nil, err
+					}
+					i := slices.IndexFunc(network.Routes, func(r hcloud.NetworkRoute) bool {
+						return r.Destination.String() == route.Destination.String() && r.Gateway.Equal(route.Gateway)
+					})
+					if i < 0 {
+						return nil, nil, fmt.Errorf("network %d has no route to %s via %s", network.ID, route.Destination, route.Gateway)
+					}
+					network.Routes = slices.Delete(slices.Clone(network.Routes), i, i+1)
+					return network, nil, nil
+				},
+				singleAction(func(ctx context.Context, network *hcloud.Network) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Network.DeleteRoute(ctx, network, hcloud.NetworkDeleteRouteOpts{Route: route})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "change_network_ip_range",
+		Description: "Extends the IP range of a Network. The new range must contain the current range. It cannot be shrunk.",
+		Handler: func(ctx context.Context, args NetworkIPRangeArgs) (*mcpgolang.ToolResponse, error) {
+			var ipRange *net.IPNet
+			return runAction(ctx, networkTarget, args.ID, args.WriteArgs, "change_ip_range", false,
+				func(ctx context.Context, network *hcloud.Network) (*hcloud.Network, []string, error) {
+					var err error
+					ipRange, err = parseIPRange("network IP range", args.IPRange)
+					if err != nil {
+						return nil, nil, err
+					}
+					if err := checkNetworkIPRange(ipRange); err != nil {
+						return nil, nil, err
+					}
+					if !containsIPRange(ipRange, network.IPRange) {
+						return nil, nil, fmt.Errorf("IP range %s does not contain the current IP range %s of network %d, it can only be extended", ipRange, network.IPRange, network.ID)
+					}
+					network.IPRange = ipRange
+					return network, nil, nil
+				},
+				singleAction(func(ctx context.Context, network *hcloud.Network) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Network.ChangeIPRange(ctx, network, hcloud.NetworkChangeIPRangeOpts{IPRange: ipRange})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "change_network_protection",
+		Description: "Enables or disables the delete protection of a Network.",
+		Handler: func(ctx context.Context, args NetworkProtectionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, networkTarget, args.ID, args.WriteArgs, "change_protection", false,
+				func(ctx context.Context, network *hcloud.Network) (*hcloud.Network, []string, error) {
+					var warnings []string
+					if network.Protection.Delete == args.Delete {
+						warnings = append(warnings, fmt.Sprintf("delete protection of network %d is already %t", network.ID, args.Delete))
+					}
+					network.Protection.Delete = args.Delete
+					return network, warnings, nil
+				},
+				singleAction(func(ctx context.Context, network *hcloud.Network) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Network.ChangeProtection(ctx, network, hcloud.NetworkChangeProtectionOpts{
+						Delete: hcloud.Ptr(args.Delete),
+					})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
 }
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Supported output formats of tool responses
+const (
+	FormatJSON          = "json"
+	FormatCompactJSON   = "compact_json"
+	FormatYAML          = "yaml"
+	FormatMarkdownTable = "markdown_table"
+	FormatCSV           = "csv"
+)
+
+var outputFormats = []string{FormatJSON, FormatCompactJSON, FormatYAML, FormatMarkdownTable, FormatCSV}
+
+// maxResponseSize is the maximum size of a tool response in bytes, 0 means unlimited.
+var maxResponseSize int
+
+// truncatedMarker is appended to responses which were cut off at the maximum response size.
+const truncatedMarker = "\n... [truncated: the response exceeds %d bytes, use paging, filters or fields to reduce it]"
+
+// OutputArgs represents the arguments controlling the response of a tool.
+// It is embedded in the arguments of every tool.
+type OutputArgs struct {
+	Fields []string `json:"fields,omitempty" jsonschema:"description=Only return these fields as dot paths (e.g. id or public_net.ipv4). For lists the fields of every item are selected"`
+	Format string   `json:"format,omitempty" jsonschema:"description=Format of the response: json (default)/compact_json/yaml/markdown_table/csv"`
+}
+
+func (a OutputArgs) outputOptions() OutputArgs {
+	return a
+}
+
+// Validate checks the output format.
+func (a OutputArgs) Validate() error {
+	if a.Format != EmptyString && !slices.Contains(outputFormats, a.Format) {
+		return fmt.Errorf("invalid format %q, must be one of %s", a.Format, strings.Join(outputFormats, ", "))
+	}
+	return nil
+}
+
+type outputKey struct{}
+
+// outputFromContext returns the output options of the tool invocation.
+func outputFromContext(ctx context.Context) OutputArgs {
+	output, _ := ctx.Value(outputKey{}).(OutputArgs)
+	return output
+}
+
+// listResponse is implemented by ListResponse, whose fields are selected per item.
+type listResponse interface {
+	isListResponse()
+}
+
+func (*ListResponse[T]) isListResponse() {}
+
+// formatResponse renders the data of a tool response with the given output options.
+func formatResponse(data any, output OutputArgs) (string, error) {
+	if len(output.Fields) == 0 && (output.Format == EmptyString || output.Format == FormatJSON) {
+		marshaled, err := json.MarshalIndent(data, "", "  ")
+		return string(marshaled), err
+	}
+
+	// Work on the JSON representation, so that field names are the same in all formats
+	marshaled, err := json.Marshal(data)
+	if err != nil {
+		return EmptyString, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(marshaled))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return EmptyString, err
+	}
+	value = normalizeNumbers(value)
+
+	_, isList := data.(listResponse)
+	if len(output.Fields) > 0 {
+		paths := parseFieldPaths(output.Fields)
+		if m, ok := value.(map[string]any); ok && isList {
+			// The pagination metadata is always returned, all other paths select fields of the items.
+			// A bare items path keeps the whole items.
+			itemPaths := make([][]string, 0, len(paths))
+			wholeItems := false
+			for _, path := range paths {
+				_, isMetadata := m[path[0]]
+				switch {
+				case path[0] == "items" && len(path) == 1:
+					wholeItems = true
+				case path[0] == "items":
+					itemPaths = append(itemPaths, path[1:])
+				case !isMetadata:
+					itemPaths = append(itemPaths, path)
+				}
+			}
+			if !wholeItems && len(itemPaths) > 0 {
+				m["items"] = selectFields(m["items"], itemPaths)
+			}
+		} else {
+			value = selectFields(value, paths)
+		}
+	}
+
+	switch output.Format {
+	case FormatCompactJSON:
+		marshaled, err = json.Marshal(value)
+		return string(marshaled), err
+	case FormatYAML:
+		marshaled, err = yaml.Marshal(value)
+		return string(marshaled), err
+	case FormatMarkdownTable, FormatCSV:
+		rows, footer := value, EmptyString
+		if m, ok := value.(map[string]any); ok && isList {
+			rows = m["items"]
+			footer = fmt.Sprintf("Page %v of %v entries in total", m["page"], m["total_entries"])
+			if m["next_page"] != nil {
+				footer += fmt.Sprintf(", next page: %v", m["next_page"])
+			}
+		}
+		columns, cells := toTable(rows)
+		if output.Format == FormatCSV {
+			return renderCSV(columns, cells)
+		}
+		return renderMarkdownTable(columns, cells, footer), nil
+	}
+
+	marshaled, err = json.MarshalIndent(value, "", "  ")
+	return string(marshaled), err
+}
+
+// normalizeNumbers converts JSON numbers to integers where possible, so that large IDs are
+// not rendered in exponent notation.
+func normalizeNumbers(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeNumbers(item)
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	}
+	return value
+}
+
+// parseFieldPaths splits dot paths into their keys. A leading $ and [*] of JSONPath are ignored.
+func parseFieldPaths(fields []string) [][]string {
+	paths := make([][]string, 0, len(fields))
+	for _, field := range fields {
+		field = strings.TrimPrefix(strings.TrimPrefix(strings.TrimSpace(field), "$"), ".")
+		field = strings.NewReplacer("[*]", EmptyString, "[]", EmptyString).Replace(field)
+		if field != EmptyString {
+			paths = append(paths, strings.Split(field, "."))
+		}
+	}
+	return paths
+}
+
+// selectFields returns only the given paths of the value. Paths are applied to every element of arrays.
+func selectFields(value any, paths [][]string) any {
+	switch v := value.(type) {
+	case []any:
+		result := make([]any, 0, len(v))
+		for _, item := range v {
+			result = append(result, selectFields(item, paths))
+		}
+		return result
+	case map[string]any:
+		result := make(map[string]any)
+		nested := make(map[string][][]string)
+		for _, path := range paths {
+			key, item, ok := lookupField(v, path[0])
+			if !ok {
+				continue
+			}
+			if len(path) == 1 {
+				result[key] = item
+				continue
+			}
+			nested[key] = append(nested[key], path[1:])
+		}
+		// Paths sharing a key are selected together, a whole field wins over its nested paths
+		for key, nestedPaths := range nested {
+			if _, ok := result[key]; !ok {
+				result[key] = selectFields(v[key], nestedPaths)
+			}
+		}
+		return result
+	}
+	return value
+}
+
+// lookupField returns the field with the given name, falling back to a case-insensitive match.
+func lookupField(m map[string]any, name string) (string, any, bool) {
+	if value, ok := m[name]; ok {
+		return name, value, true
+	}
+	for key, value := range m {
+		if strings.EqualFold(key, name) {
+			return key, value, true
+		}
+	}
+	return EmptyString, nil, false
+}
+
+// toTable flattens the value into rows of cells. Nested objects become dot path columns,
+// arrays are rendered as compact JSON. The id and name columns come first.
+func toTable(value any) ([]string, []map[string]string) {
+	var items []any
+	switch v := value.(type) {
+	case []any:
+		items = v
+	case nil:
+	default:
+		items = []any{v}
+	}
+
+	var columns []string
+	rows := make([]map[string]string, 0, len(items))
+	for _, item := range items {
+		row := make(map[string]string)
+		if m, ok := item.(map[string]any); ok {
+			flattenCells(EmptyString, m, row)
+		} else {
+			flattenCells("value", item, row)
+		}
+		for column := range row {
+			if !slices.Contains(columns, column) {
+				columns = append(columns, column)
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	rank := func(column string) int {
+		switch column {
+		case "id":
+			return 0
+		case "name":
+			return 1
+		}
+		return 2
+	}
+	slices.SortFunc(columns, func(a, b string) int {
+		if rank(a) != rank(b) {
+			return rank(a) - rank(b)
+		}
+		return strings.Compare(a, b)
+	})
+
+	return columns, rows
+}
+
+func flattenCells(prefix string, value any, row map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if prefix != EmptyString {
+				key = prefix + "." + key
+			}
+			flattenCells(key, item, row)
+		}
+	case nil:
+		row[prefix] = EmptyString
+	case string:
+		row[prefix] = v
+	case []any:
+		marshaled, _ := json.Marshal(v)
+		row[prefix] = string(marshaled)
+	default:
+		row[prefix] = fmt.Sprint(v)
+	}
+}
+
+func renderMarkdownTable(columns []string, rows []map[string]string, footer string) string {
+	escape := strings.NewReplacer("|", "\\|", "\n", " ")
+
+	var b strings.Builder
+	if len(columns) == 0 {
+		b.WriteString("No results\n")
+		if footer != EmptyString {
+			b.WriteString("\n" + footer + "\n")
+		}
+		return b.String()
+	}
+	b.WriteString("| " + strings.Join(columns, " | ") + " |\n")
+	b.WriteString("|" + strings.Repeat(" --- |", len(columns)) + "\n")
+	for _, row := range rows {
+		cells := make([]string, 0, len(columns))
+		for _, column := range columns {
+			cells = append(cells, escape.Replace(row[column]))
+		}
+		b.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+	if footer != EmptyString {
+		b.WriteString("\n" + footer + "\n")
+	}
+	return b.String()
+}
+
+func renderCSV(columns []string, rows []map[string]string) (string, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.Write(columns); err != nil {
+		return EmptyString, err
+	}
+	for _, row := range rows {
+		record := make([]string, 0, len(columns))
+		for _, column := range columns {
+			record = append(record, row[column])
+		}
+		if err := w.Write(record); err != nil {
+			return EmptyString, err
+		}
+	}
+	w.Flush()
+	return b.String(), w.Error()
+}
+
+// truncateResponse cuts the text off at the maximum response size and marks it as truncated.
+func truncateResponse(text string) string {
+	if maxResponseSize <= 0 || len(text) <= maxResponseSize {
+		return text
+	}
+	cut := maxResponseSize
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + fmt.Sprintf(truncatedMarker, maxResponseSize)
+}
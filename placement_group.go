@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	mcpgolang "github.com/metoro-io/mcp-golang"
@@ -11,17 +12,65 @@ import (
 // It contains the PlacementGroup ID or Name that is needed to perform the lookup.
 type PlacementGroupReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The Placement Group id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// PlacementGroupListArgs represents the arguments for listing PlacementGroups.
+// PlacementGroups can be filtered by name and type on top of the label selector.
+type PlacementGroupListArgs struct {
+	Name string `json:"name,omitempty" jsonschema:"description=Only return the placement group with this name"`
+	Type string `json:"type,omitempty" jsonschema:"description=Only return placement groups of this type (spread)"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
+}
+
+type PlacementGroupResponse struct {
+	ID      int64             `json:"id" jsonschema:"required,description=Unique identifier of the placement group"`
+	Name    string            `json:"name" jsonschema:"required,description=The name of the placement group"`
+	Type    string            `json:"type" jsonschema:"required,description=The type of the placement group (e.g. spread)"`
+	Servers []int64           `json:"servers" jsonschema:"description=IDs of the servers in the placement group"`
+	Labels  map[string]string `json:"labels" jsonschema:"description=User-defined labels for the placement group"`
+	Created time.Time         `json:"created" jsonschema:"required,description=Timestamp of when the placement group was created"`
+}
+
+func toPlacementGroupResponse(p *hcloud.PlacementGroup) *PlacementGroupResponse {
+	if p == nil {
+		return nil
+	}
+
+	return &PlacementGroupResponse{
+		ID:      p.ID,
+		Name:    p.Name,
+		Type:    string(p.Type),
+		Servers: p.Servers,
+		Labels:  p.Labels,
+		Created: p.Created,
+	}
 }
 
 // PlacementGroupTools
 var placementGroupTools = []Tool{
 	{
 		Name:        "get_all_placement_groups",
-		Description: "Returns all PlacementGroups objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.PlacementGroup, error) {
-				result, err := client.PlacementGroup.All(context.Background())
-				return result, err
+		Description: "Returns a page of PlacementGroups, optionally filtered by name, type and label selector.",
+		Handler: func(ctx context.Context, args PlacementGroupListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*PlacementGroupResponse], error) {
+				listOpts, err := args.listOpts()
+				if err != nil {
+					return nil, err
+				}
+				opts := hcloud.PlacementGroupListOpts{
+					ListOpts: listOpts,
+					Name:     args.Name,
+					Type:     hcloud.PlacementGroupType(args.Type),
+				}
+				result, resp, err := hcloudClient(ctx).PlacementGroup.List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return newListResponse(toResponseList(result, toPlacementGroupResponse), resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -29,10 +78,16 @@ var placementGroupTools = []Tool{
 	{
 		Name:        "get_a_placement_group_by_id_or_name",
 		Description: "Retrieves a PlacementGroup by its ID or Name.",
-		Handler: func(args PlacementGroupReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.PlacementGroup, error) {
-				result, _, err := client.PlacementGroup.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args PlacementGroupReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*PlacementGroupResponse, error) {
+				result, _, err := hcloudClient(ctx).PlacementGroup.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return toPlacementGroupResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
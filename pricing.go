@@ -7,15 +7,114 @@ import (
 	mcpgolang "github.com/metoro-io/mcp-golang"
 )
 
+// PriceResponse represents a price in the currency of the project.
+type PriceResponse struct {
+	Currency string `json:"currency,omitempty" jsonschema:"description=Currency of the price (e.g. EUR)"`
+	VATRate  string `json:"vat_rate,omitempty" jsonschema:"description=VAT rate in percent"`
+	Net      string `json:"net" jsonschema:"required,description=Price without VAT"`
+	Gross    string `json:"gross" jsonschema:"required,description=Price including VAT"`
+}
+
+func toPriceResponse(p hcloud.Price) PriceResponse {
+	return PriceResponse{
+		Currency: p.Currency,
+		VATRate:  p.VATRate,
+		Net:      p.Net,
+		Gross:    p.Gross,
+	}
+}
+
+// LocationPricingResponse represents the prices of a server type or load balancer type in a location.
+type LocationPricingResponse struct {
+	Location        string        `json:"location" jsonschema:"required,description=The name of the location"`
+	Hourly          PriceResponse `json:"hourly" jsonschema:"required,description=Hourly price"`
+	Monthly         PriceResponse `json:"monthly" jsonschema:"required,description=Monthly price"`
+	IncludedTraffic uint64        `json:"included_traffic" jsonschema:"description=Included traffic in bytes"`
+	PerTBTraffic    PriceResponse `json:"per_tb_traffic" jsonschema:"description=Price per additional TB of traffic"`
+}
+
+func toLocationPricingResponse(location *hcloud.Location, hourly, monthly hcloud.Price, includedTraffic uint64, perTBTraffic hcloud.Price) LocationPricingResponse {
+	var name string
+	if location != nil {
+		name = location.Name
+	}
+	return LocationPricingResponse{
+		Location:        name,
+		Hourly:          toPriceResponse(hourly),
+		Monthly:         toPriceResponse(monthly),
+		IncludedTraffic: includedTraffic,
+		PerTBTraffic:    toPriceResponse(perTBTraffic),
+	}
+}
+
+// TypePricingResponse represents the prices of a floating ip, primary ip, server or load balancer type.
+type TypePricingResponse struct {
+	Type   string                    `json:"type" jsonschema:"required,description=The name of the type (e.g. ipv4 or cx22)"`
+	Prices []LocationPricingResponse `json:"prices" jsonschema:"required,description=Prices per location"`
+}
+
+// PricingResponse represents the prices of all resources of the project.
+type PricingResponse struct {
+	ImagePerGBMonth        PriceResponse         `json:"image_per_gb_month" jsonschema:"required,description=Price of snapshots and backups per GB and month"`
+	VolumePerGBMonth       PriceResponse         `json:"volume_per_gb_month" jsonschema:"required,description=Price of volumes per GB and month"`
+	ServerBackupPercentage string                `json:"server_backup_percentage" jsonschema:"required,description=Backups cost this percentage of the server price"`
+	FloatingIPs            []TypePricingResponse `json:"floating_ips" jsonschema:"description=Prices of floating ips per type"`
+	PrimaryIPs             []TypePricingResponse `json:"primary_ips" jsonschema:"description=Prices of primary ips per type"`
+	ServerTypes            []TypePricingResponse `json:"server_types" jsonschema:"description=Prices of server types"`
+	LoadBalancerTypes      []TypePricingResponse `json:"load_balancer_types" jsonschema:"description=Prices of load balancer types"`
+}
+
+func toPricingResponse(p hcloud.Pricing) *PricingResponse {
+	result := &PricingResponse{
+		ImagePerGBMonth:        toPriceResponse(p.Image.PerGBMonth),
+		VolumePerGBMonth:       toPriceResponse(p.Volume.PerGBMonthly),
+		ServerBackupPercentage: p.ServerBackup.Percentage,
+	}
+
+	for _, t := range p.FloatingIPs {
+		prices := make([]LocationPricingResponse, 0, len(t.Pricings))
+		for _, price := range t.Pricings {
+			prices = append(prices, toLocationPricingResponse(price.Location, hcloud.Price{}, price.Monthly, 0, hcloud.Price{}))
+		}
+		result.FloatingIPs = append(result.FloatingIPs, TypePricingResponse{Type: string(t.Type), Prices: prices})
+	}
+	for _, t := range p.PrimaryIPs {
+		prices := make([]LocationPricingResponse, 0, len(t.Pricings))
+		for _, price := range t.Pricings {
+			prices = append(prices, LocationPricingResponse{
+				Location: price.Location,
+				Hourly:   PriceResponse{Net: price.Hourly.Net, Gross: price.Hourly.Gross},
+				Monthly:  PriceResponse{Net: price.Monthly.Net, Gross: price.Monthly.Gross},
+			})
+		}
+		result.PrimaryIPs = append(result.PrimaryIPs, TypePricingResponse{Type: t.Type, Prices: prices})
+	}
+	for _, t := range p.ServerTypes {
+		if t.ServerType != nil {
+			result.ServerTypes = append(result.ServerTypes, TypePricingResponse{Type: t.ServerType.Name, Prices: toServerTypePrices(t.Pricings)})
+		}
+	}
+	for _, t := range p.LoadBalancerTypes {
+		if t.LoadBalancerType != nil {
+			result.LoadBalancerTypes = append(result.LoadBalancerTypes, TypePricingResponse{Type: t.LoadBalancerType.Name, Prices: toLoadBalancerTypePrices(t.Pricings)})
+		}
+	}
+
+	return result
+}
+
 // PriceTools
 var priceTools = []Tool{
 	{
 		Name:        "get_pricing_information",
 		Description: "Get retrieves pricing information.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (hcloud.Pricing, error) {
-				result, _, err := client.Pricing.Get(context.Background())
-				return result, err
+		Handler: func(ctx context.Context, _ NoArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*PricingResponse, error) {
+				result, _, err := hcloudClient(ctx).Pricing.Get(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return toPricingResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	mcpgolang "github.com/metoro-io/mcp-golang"
@@ -11,23 +13,274 @@ import (
 // It contains the PrimaryIP ID or Name that is needed to perform the lookup.
 type PrimaryIPReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The Primary IP id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// PrimaryIPListArgs represents the arguments for listing PrimaryIPs.
+// PrimaryIPs can be filtered by name and ip on top of the label selector.
+type PrimaryIPListArgs struct {
+	Name string `json:"name,omitempty" jsonschema:"description=Only return the primary ip with this name"`
+	IP   string `json:"ip,omitempty" jsonschema:"description=Only return the primary ip with this ip address"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
 }
 
 // PrimaryIPReadByIPArgs represents the arguments required to read an PrimaryIP by IP.
 // It contains the PrimaryIP IP that is needed to perform the lookup.
 type PrimaryIPReadByIPArgs struct {
 	IP string `json:"ip" jsonschema:"required,description=The Primary IP ip to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// PrimaryIPCreateArgs represents the arguments required to create a Primary IP.
+// The Primary IP is either created in a datacenter or assigned to a server right away.
+type PrimaryIPCreateArgs struct {
+	Type       string            `json:"type" jsonschema:"required,description=The ip type (ipv4/ipv6)"`
+	Name       string            `json:"name" jsonschema:"required,description=The primary ip name"`
+	Datacenter string            `json:"datacenter,omitempty" jsonschema:"description=The datacenter id or name to create the primary ip in (e.g. fsn1-dc14). Either datacenter or server is required"`
+	Server     int64             `json:"server,omitempty" jsonschema:"description=The id of the server to assign the primary ip to. The server must be off"`
+	AutoDelete *bool             `json:"auto_delete,omitempty" jsonschema:"description=Whether the primary ip is deleted together with its server (defaults to false)"`
+	Labels     map[string]string `json:"labels,omitempty" jsonschema:"description=User-defined labels for the primary ip"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// PrimaryIPActionArgs represents the arguments required to run an action on a Primary IP.
+// It contains the Primary IP ID the action is performed on.
+type PrimaryIPActionArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The primary ip id"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// PrimaryIPUpdateArgs represents the arguments required to rename a Primary IP, change its auto delete setting or replace its labels.
+type PrimaryIPUpdateArgs struct {
+	ID         int64             `json:"id" jsonschema:"required,description=The primary ip id"`
+	Name       string            `json:"name,omitempty" jsonschema:"description=New name of the primary ip"`
+	AutoDelete *bool             `json:"auto_delete,omitempty" jsonschema:"description=Whether the primary ip is deleted together with its server"`
+	Labels     map[string]string `json:"labels,omitempty" jsonschema:"description=New labels of the primary ip. Replaces all existing labels"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// PrimaryIPAssignArgs represents the arguments required to assign a Primary IP to a Server.
+type PrimaryIPAssignArgs struct {
+	ID     int64 `json:"id" jsonschema:"required,description=The primary ip id"`
+	Server int64 `json:"server" jsonschema:"required,description=The id of the server to assign the primary ip to. The server must be off and in the datacenter of the primary ip"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+type PrimaryIPResponse struct {
+	ID           int64             `json:"id" jsonschema:"required,description=Unique identifier of the primary ip"`
+	Name         string            `json:"name" jsonschema:"required,description=The name of the primary ip"`
+	Type         string            `json:"type" jsonschema:"required,description=The type of the primary ip either of ipv4 or ipv6"`
+	IP           string            `json:"ip" jsonschema:"required,description=The ip address; for ipv6 the first address of the network"`
+	Network      string            `json:"network,omitempty" jsonschema:"description=The ipv6 network in CIDR notation"`
+	AssigneeID   int64             `json:"assignee_id,omitempty" jsonschema:"description=ID of the resource the primary ip is assigned to; 0 if it is unassigned"`
+	AssigneeType string            `json:"assignee_type" jsonschema:"description=Type of the resource the primary ip can be assigned to (server)"`
+	AutoDelete   bool              `json:"auto_delete" jsonschema:"required,description=Whether the primary ip is deleted together with its server"`
+	Datacenter   *ResourceRef      `json:"datacenter" jsonschema:"required,description=The datacenter of the primary ip"`
+	DNSPtr       map[string]string `json:"dns_ptr" jsonschema:"description=Reverse DNS entries per ip address"`
+	Blocked      bool              `json:"blocked" jsonschema:"required,description=Whether the primary ip is blocked"`
+	Protection   DeleteProtection  `json:"protection" jsonschema:"required,description=Protection settings of the primary ip"`
+	Labels       map[string]string `json:"labels" jsonschema:"description=User-defined labels for the primary ip"`
+	Created      time.Time         `json:"created" jsonschema:"required,description=Timestamp of when the primary ip was created"`
+}
+
+func toPrimaryIPResponse(p *hcloud.PrimaryIP) *PrimaryIPResponse {
+	if p == nil {
+		return nil
+	}
+
+	var network string
+	if p.Type == hcloud.PrimaryIPTypeIPv6 {
+		network = ipNetString(p.Network)
+	}
+
+	return &PrimaryIPResponse{
+		ID:           p.ID,
+		Name:         p.Name,
+		Type:         string(p.Type),
+		IP:           ipString(p.IP),
+		Network:      network,
+		AssigneeID:   p.AssigneeID,
+		AssigneeType: p.AssigneeType,
+		AutoDelete:   p.AutoDelete,
+		Datacenter:   toDatacenterRef(p.Datacenter),
+		DNSPtr:       p.DNSPtr,
+		Blocked:      p.Blocked,
+		Protection:   DeleteProtection{Delete: p.Protection.Delete},
+		Labels:       p.Labels,
+		Created:      p.Created,
+	}
+}
+
+type PrimaryIPCreateResponse struct {
+	PrimaryIP *PrimaryIPResponse `json:"primary_ip" jsonschema:"required,description=The created primary ip"`
+	Action    *ActionResponse    `json:"action" jsonschema:"description=The action creating the primary ip; null if none was needed"`
+}
+
+type PrimaryIPActionResponse struct {
+	PrimaryIP *PrimaryIPResponse `json:"primary_ip" jsonschema:"description=The primary ip the action was performed on; null if it was deleted"`
+	Action    *ActionResponse    `json:"action" jsonschema:"description=The triggered action; null if the change did not need one"`
+}
+
+// checkServerOff fails if the Server is not off. Primary IPs can only be assigned to and unassigned from stopped servers.
+func checkServerOff(server *hcloud.Server, change string) error {
+	if server.Status != hcloud.ServerStatusOff {
+		return fmt.Errorf("server %d is %s, it must be off to %s; stop it with shutdown_a_server or power_off_a_server first", server.ID, server.Status, change)
+	}
+	return nil
+}
+
+// serverPrimaryIPID returns the ID of the Primary IP of the given type assigned to the Server, 0 if there is none.
+func serverPrimaryIPID(server *hcloud.Server, ipType hcloud.PrimaryIPType) int64 {
+	if ipType == hcloud.PrimaryIPTypeIPv6 {
+		return server.PublicNet.IPv6.ID
+	}
+	return server.PublicNet.IPv4.ID
+}
+
+// getPrimaryIPServer retrieves the Server a Primary IP is assigned to and checks that it can be assigned to or unassigned from it.
+func getPrimaryIPServer(ctx context.Context, id int64, change string) (*hcloud.Server, error) {
+	server, err := getServer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkScope("server", server.ID, server.Labels); err != nil {
+		return nil, err
+	}
+	if err := checkServerOff(server, change); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+func toPrimaryIPCreateOpts(ctx context.Context, args PrimaryIPCreateArgs) (hcloud.PrimaryIPCreateOpts, *hcloud.PrimaryIP, error) {
+	opts := hcloud.PrimaryIPCreateOpts{
+		Name:         args.Name,
+		Type:         hcloud.PrimaryIPType(args.Type),
+		AssigneeType: "server",
+		AutoDelete:   args.AutoDelete,
+	}
+	expected := &hcloud.PrimaryIP{
+		Name:         args.Name,
+		Type:         opts.Type,
+		AssigneeType: opts.AssigneeType,
+		AutoDelete:   args.AutoDelete != nil && *args.AutoDelete,
+		Created:      time.Now(),
+	}
+	if opts.Type != hcloud.PrimaryIPTypeIPv4 && opts.Type != hcloud.PrimaryIPTypeIPv6 {
+		return opts, nil, fmt.Errorf("invalid type %q, must be one of ipv4, ipv6", args.Type)
+	}
+	if args.Name == EmptyString {
+		return opts, nil, fmt.Errorf("missing primary ip name")
+	}
+	labels, err := scopeLabels(args.Labels)
+	if err != nil {
+		return opts, nil, err
+	}
+	opts.Labels, expected.Labels = labels, labels
+
+	switch {
+	case args.Datacenter != EmptyString && args.Server != 0:
+		return opts, nil, fmt.Errorf("either datacenter or server is allowed, not both")
+	case args.Server != 0:
+		server, err := getPrimaryIPServer(ctx, args.Server, "assign a primary ip")
+		if err != nil {
+			return opts, nil, err
+		}
+		if id := serverPrimaryIPID(server, opts.Type); id != 0 {
+			return opts, nil, fmt.Errorf("server %d already has the primary %s %d, unassign it first", server.ID, opts.Type, id)
+		}
+		opts.AssigneeID = hcloud.Ptr(server.ID)
+		expected.AssigneeID = server.ID
+		expected.Datacenter = server.Datacenter
+	case args.Datacenter != EmptyString:
+		datacenter, _, err := hcloudClient(ctx).Datacenter.Get(ctx, args.Datacenter)
+		if err != nil {
+			return opts, nil, err
+		}
+		if datacenter == nil {
+			return opts, nil, fmt.Errorf("datacenter %s not found", args.Datacenter)
+		}
+		opts.Datacenter = datacenter.Name
+		expected.Datacenter = datacenter
+	default:
+		return opts, nil, fmt.Errorf("either datacenter or server is required")
+	}
+	return opts, expected, nil
+}
+
+// planPrimaryIPCreate validates the options for creating a Primary IP and returns the planned change.
+func planPrimaryIPCreate(ctx context.Context, opts hcloud.PrimaryIPCreateOpts, expected *hcloud.PrimaryIP) (*PlannedChange, error) {
+	existing, _, err := hcloudClient(ctx).PrimaryIP.GetByName(ctx, opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("primary ip %s already exists", opts.Name)
+	}
+
+	return newPlannedChange("create_primary_ip", &PrimaryIPCreateResponse{
+		PrimaryIP: toPrimaryIPResponse(expected),
+		Action:    plannedAction("create_primary_ip"),
+	}), nil
+}
+
+// getPrimaryIP retrieves a Primary IP by its ID and fails if the Primary IP does not exist.
+func getPrimaryIP(ctx context.Context, id int64) (*hcloud.PrimaryIP, error) {
+	primaryIP, _, err := hcloudClient(ctx).PrimaryIP.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if primaryIP == nil {
+		return nil, fmt.Errorf("primary ip %d not found", id)
+	}
+	return primaryIP, nil
+}
+
+// primaryIPTarget runs actions on Primary IPs with runAction.
+var primaryIPTarget = actionTarget[hcloud.PrimaryIP, PrimaryIPResponse]{
+	name:       "primary ip",
+	actionType: "primary_ip",
+	get:        getPrimaryIP,
+	labels:     func(primaryIP *hcloud.PrimaryIP) map[string]string { return primaryIP.Labels },
+	convert:    toPrimaryIPResponse,
+	respond: func(primaryIP *PrimaryIPResponse, actions []*ActionResponse) any {
+		return &PrimaryIPActionResponse{PrimaryIP: primaryIP, Action: firstAction(actions)}
+	},
 }
 
 // PrimaryIPTools
 var primaryIPTools = []Tool{
 	{
 		Name:        "get_all_primary_ips",
-		Description: "Returns all PrimaryIPs objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.PrimaryIP, error) {
-				result, err := client.PrimaryIP.All(context.Background())
-				return result, err
+		Description: "Returns a page of PrimaryIPs, optionally filtered by name, ip and label selector.",
+		Handler: func(ctx context.Context, args PrimaryIPListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*PrimaryIPResponse], error) {
+				listOpts, err := args.listOpts()
+				if err != nil {
+					return nil, err
+				}
+				opts := hcloud.PrimaryIPListOpts{
+					ListOpts: listOpts,
+					Name:     args.Name,
+					IP:       args.IP,
+				}
+				result, resp, err := hcloudClient(ctx).PrimaryIP.List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return newListResponse(toResponseList(result, toPrimaryIPResponse), resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -35,10 +288,16 @@ var primaryIPTools = []Tool{
 	{
 		Name:        "get_a_primary_ip_by_id_or_name",
 		Description: "Retrieves a PrimaryIP by its ID or Name. Get retrieves a Primary IP by its ID if the input can be parsed as an integer, otherwise it retrieves a Primary IP by its name. If the Primary IP does not exist, nil is returned.",
-		Handler: func(args PrimaryIPReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.PrimaryIP, error) {
-				result, _, err := client.PrimaryIP.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args PrimaryIPReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*PrimaryIPResponse, error) {
+				result, _, err := hcloudClient(ctx).PrimaryIP.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return toPrimaryIPResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -46,12 +305,184 @@ var primaryIPTools = []Tool{
 	{
 		Name:        "get_a_primary_ip_by_ip",
 		Description: "Retrieves a PrimaryIP by its IP. If the PrimaryIP does not exist, nil is returned.",
-		Handler: func(args PrimaryIPReadByIPArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.PrimaryIP, error) {
-				result, _, err := client.PrimaryIP.GetByIP(context.Background(), args.IP)
-				return result, err
+		Handler: func(ctx context.Context, args PrimaryIPReadByIPArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*PrimaryIPResponse, error) {
+				result, _, err := hcloudClient(ctx).PrimaryIP.GetByIP(ctx, args.IP)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return toPrimaryIPResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
 	},
+	{
+		Name:        "create_a_primary_ip",
+		Description: "Creates a new Primary IP in a datacenter or assigned to a Server. The server must be off and must not have a primary ip of the same type.",
+		Handler: func(ctx context.Context, args PrimaryIPCreateArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				opts, expected, err := toPrimaryIPCreateOpts(ctx, args)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					return planPrimaryIPCreate(ctx, opts, expected)
+				}
+				result, _, err := hcloudClient(ctx).PrimaryIP.Create(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				recordActions(ctx, result.Action)
+				if result.Action != nil && args.Wait {
+					result.Action, err = waitForAction(ctx, result.Action)
+					if err != nil {
+						return nil, err
+					}
+					result.PrimaryIP, _, err = hcloudClient(ctx).PrimaryIP.GetByID(ctx, result.PrimaryIP.ID)
+					if err != nil {
+						return nil, err
+					}
+				}
+				return &PrimaryIPCreateResponse{
+					PrimaryIP: toPrimaryIPResponse(result.PrimaryIP),
+					Action:    toActionResponse(result.Action),
+				}, nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "update_a_primary_ip",
+		Description: "Renames a Primary IP, changes whether it is deleted together with its server or replaces its labels.",
+		Handler: func(ctx context.Context, args PrimaryIPUpdateArgs) (*mcpgolang.ToolResponse, error) {
+			opts := hcloud.PrimaryIPUpdateOpts{
+				Name:       args.Name,
+				AutoDelete: args.AutoDelete,
+			}
+			return runAction(ctx, primaryIPTarget, args.ID, args.WriteArgs, "update_primary_ip", true,
+				func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*PrimaryIPResponse, []string, error) {
+					if args.Name == EmptyString && args.AutoDelete == nil && args.Labels == nil {
+						return nil, nil, fmt.Errorf("either name, auto_delete or labels is required")
+					}
+					expected := toPrimaryIPResponse(primaryIP)
+					if args.Name != EmptyString {
+						expected.Name = args.Name
+					}
+					if args.AutoDelete != nil {
+						expected.AutoDelete = *args.AutoDelete
+					}
+					if args.Labels != nil {
+						labels, err := scopeLabels(args.Labels)
+						if err != nil {
+							return nil, nil, err
+						}
+						opts.Labels = &labels
+						expected.Labels = labels
+					}
+					return expected, nil, nil
+				},
+				singleAction(func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*hcloud.Action, error) {
+					_, _, err := hcloudClient(ctx).PrimaryIP.Update(ctx, primaryIP, opts)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "assign_primary_ip",
+		Description: "Assigns an unassigned Primary IP to a Server. The server must be off, in the datacenter of the primary ip and without a primary ip of the same type.",
+		Handler: func(ctx context.Context, args PrimaryIPAssignArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, primaryIPTarget, args.ID, args.WriteArgs, "assign_primary_ip", false,
+				func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*PrimaryIPResponse, []string, error) {
+					if primaryIP.AssigneeID != 0 {
+						return nil, nil, fmt.Errorf("primary ip %d is assigned to server %d, unassign it first", primaryIP.ID, primaryIP.AssigneeID)
+					}
+					server, err := getPrimaryIPServer(ctx, args.Server, "assign a primary ip")
+					if err != nil {
+						return nil, nil, err
+					}
+					if server.Datacenter != nil && primaryIP.Datacenter != nil && server.Datacenter.ID != primaryIP.Datacenter.ID {
+						return nil, nil, fmt.Errorf("server %d is in datacenter %s, primary ip %d is in datacenter %s", server.ID, server.Datacenter.Name, primaryIP.ID, primaryIP.Datacenter.Name)
+					}
+					if id := serverPrimaryIPID(server, primaryIP.Type); id != 0 {
+						return nil, nil, fmt.Errorf("server %d already has the primary %s %d, unassign it first", server.ID, primaryIP.Type, id)
+					}
+					expected := toPrimaryIPResponse(primaryIP)
+					expected.AssigneeID = server.ID
+					return expected, nil, nil
+				},
+				singleAction(func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).PrimaryIP.Assign(ctx, hcloud.PrimaryIPAssignOpts{
+						ID:           primaryIP.ID,
+						AssigneeID:   args.Server,
+						AssigneeType: "server",
+					})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "unassign_primary_ip",
+		Description: "Unassigns a Primary IP from its Server. The server must be off. The primary ip is kept and can be assigned again.",
+		Handler: func(ctx context.Context, args PrimaryIPActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, primaryIPTarget, args.ID, args.WriteArgs, "unassign_primary_ip", false,
+				func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*PrimaryIPResponse, []string, error) {
+					if primaryIP.AssigneeID == 0 {
+						return nil, nil, fmt.Errorf("primary ip %d is not assigned to a server", primaryIP.ID)
+					}
+					server, err := getPrimaryIPServer(ctx, primaryIP.AssigneeID, "unassign a primary ip")
+					if err != nil {
+						return nil, nil, err
+					}
+					var warnings []string
+					if primaryIP.AutoDelete {
+						warnings = append(warnings, fmt.Sprintf("primary ip %d is no longer deleted together with server %d", primaryIP.ID, server.ID))
+					}
+					other := hcloud.PrimaryIPTypeIPv6
+					if primaryIP.Type == hcloud.PrimaryIPTypeIPv6 {
+						other = hcloud.PrimaryIPTypeIPv4
+					}
+					if serverPrimaryIPID(server, other) == 0 && len(server.PrivateNet) == 0 {
+						warnings = append(warnings, fmt.Sprintf("server %d will have no public ip and no network left", server.ID))
+					}
+					expected := toPrimaryIPResponse(primaryIP)
+					expected.AssigneeID = 0
+					return expected, warnings, nil
+				},
+				singleAction(func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).PrimaryIP.Unassign(ctx, primaryIP.ID)
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_a_primary_ip",
+		Description: "Deletes a Primary IP. An assigned primary ip is unassigned first, its server must be off. The address is released, it cannot be undone.",
+		Handler: func(ctx context.Context, args PrimaryIPActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, primaryIPTarget, args.ID, args.WriteArgs, "delete_primary_ip", true,
+				func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*PrimaryIPResponse, []string, error) {
+					if primaryIP.Protection.Delete {
+						return nil, nil, fmt.Errorf("primary ip %d is protected against deletion", primaryIP.ID)
+					}
+					var warnings []string
+					if primaryIP.AssigneeID != 0 {
+						if _, err := getPrimaryIPServer(ctx, primaryIP.AssigneeID, "delete its primary ip"); err != nil {
+							return nil, nil, err
+						}
+						warnings = append(warnings, fmt.Sprintf("primary ip %d will be unassigned from server %d", primaryIP.ID, primaryIP.AssigneeID))
+					}
+					return nil, warnings, nil
+				},
+				singleAction(func(ctx context.Context, primaryIP *hcloud.PrimaryIP) (*hcloud.Action, error) {
+					_, err := hcloudClient(ctx).PrimaryIP.Delete(ctx, primaryIP)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
 }
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	mcpgolang "github.com/metoro-io/mcp-golang"
+)
+
+// DefaultProjectName is the name of the project built from the token if no projects are configured.
+const DefaultProjectName = "default"
+
+// ProjectConfig represents a Hetzner Cloud project in the configuration file.
+type ProjectConfig struct {
+	Name        string      `yaml:"name"`
+	Token       string      `yaml:"token"`
+	TokenFile   string      `yaml:"token_file"`
+	Restriction Restriction `yaml:"restriction"`
+}
+
+// Project represents a Hetzner Cloud project the server can manage.
+// Every project has its own API token, client and default restriction.
+type Project struct {
+	Name        string
+	Restriction Restriction
+	client      *hcloud.Client
+}
+
+// projects are the projects of the server by name.
+var projects map[string]*Project
+
+// defaultProject is the name of the project used by tools if no project is given.
+var defaultProject string
+
+// ProjectArgs represents the argument selecting the project a tool works on.
+// It is embedded in the arguments of every tool.
+type ProjectArgs struct {
+	Project string `json:"project,omitempty" jsonschema:"description=Name of the Hetzner Cloud project to use (see list_projects). Uses the default project if empty"`
+}
+
+func (a ProjectArgs) projectName() string {
+	return a.Project
+}
+
+// ProjectListArgs represents the arguments for listing projects.
+type ProjectListArgs struct {
+	OutputArgs
+}
+
+type ProjectResponse struct {
+	Name        string      `json:"name" jsonschema:"required,description=The name of the project"`
+	Restriction Restriction `json:"restriction" jsonschema:"required,description=The default restriction of tools on the project"`
+	Default     bool        `json:"default" jsonschema:"required,description=Whether tools use the project if no project is given"`
+}
+
+// newProjects creates a client with the given options for every configured project.
+// Projects without a restriction inherit the restriction of the server.
+func newProjects(configs []ProjectConfig, defaultName string, restriction Restriction, opts ...hcloud.ClientOption) (map[string]*Project, string, error) {
+	if len(configs) == 0 {
+		return nil, EmptyString, fmt.Errorf("no projects configured")
+	}
+
+	result := make(map[string]*Project, len(configs))
+	for i, config := range configs {
+		if config.Name == EmptyString {
+			return nil, EmptyString, fmt.Errorf("project %d has no name", i+1)
+		}
+		if _, ok := result[config.Name]; ok {
+			return nil, EmptyString, fmt.Errorf("project %s is configured more than once", config.Name)
+		}
+		if config.Token == EmptyString && config.TokenFile != EmptyString {
+			token, err := readTokenFile(config.TokenFile)
+			if err != nil {
+				return nil, EmptyString, fmt.Errorf("project %s: %w", config.Name, err)
+			}
+			config.Token = token
+		}
+		if config.Token == EmptyString {
+			return nil, EmptyString, fmt.Errorf("project %s has no token", config.Name)
+		}
+		switch config.Restriction {
+		case EmptyString:
+			config.Restriction = restriction
+		case RestrictionReadOnly, RestrictionReadWrite:
+		default:
+			return nil, EmptyString, fmt.Errorf("project %s has invalid restriction %q", config.Name, config.Restriction)
+		}
+
+		result[config.Name] = &Project{
+			Name:        config.Name,
+			Restriction: config.Restriction,
+			client:      hcloud.NewClient(append(opts, hcloud.WithToken(config.Token))...),
+		}
+	}
+
+	if defaultName == EmptyString {
+		defaultName = configs[0].Name
+	}
+	if _, ok := result[defaultName]; !ok {
+		return nil, EmptyString, fmt.Errorf("default project %s is not configured", defaultName)
+	}
+
+	return result, defaultName, nil
+}
+
+// getProject returns the project with the given name, or the default project if the name is empty.
+func getProject(name string) (*Project, error) {
+	if name == EmptyString {
+		name = defaultProject
+	}
+	project, ok := projects[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown project %q, see list_projects for the available projects", name)
+	}
+	return project, nil
+}
+
+type projectKey struct{}
+
+// hcloudClient returns the client of the project selected for the tool invocation,
+// or the client of the default project if no project was selected.
+func hcloudClient(ctx context.Context) *hcloud.Client {
+	if project, ok := ctx.Value(projectKey{}).(*Project); ok {
+		return project.client
+	}
+	return projects[defaultProject].client
+}
+
+// ProjectTools
+var projectTools = []Tool{
+	{
+		Name:        "list_projects",
+		Description: "Returns all Hetzner Cloud projects the server can manage with their default restriction. Every tool accepts the name of a project in its project argument.",
+		Handler: func(ctx context.Context, _ ProjectListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() ([]*ProjectResponse, error) {
+				result := make([]*ProjectResponse, 0, len(projects))
+				for _, project := range projects {
+					result = append(result, &ProjectResponse{
+						Name:        project.Name,
+						Restriction: project.Restriction,
+						Default:     project.Name == defaultProject,
+					})
+				}
+				slices.SortFunc(result, func(a, b *ProjectResponse) int { return strings.Compare(a.Name, b.Name) })
+				return result, nil
+			})
+		},
+		Restriction: RestrictionReadOnly,
+	},
+}
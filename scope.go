@@ -0,0 +1,238 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// labelOperator represents the operator of a label selector requirement.
+type labelOperator string
+
+const (
+	labelOperatorEquals    labelOperator = "="
+	labelOperatorNotEquals labelOperator = "!="
+	labelOperatorExists    labelOperator = "exists"
+	labelOperatorNotExists labelOperator = "!exists"
+	labelOperatorIn        labelOperator = "in"
+	labelOperatorNotIn     labelOperator = "notin"
+)
+
+// labelRequirement represents a single requirement of a label selector, e.g. team=platform.
+type labelRequirement struct {
+	Key      string
+	Operator labelOperator
+	Values   []string
+}
+
+func (r labelRequirement) matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case labelOperatorEquals:
+		return ok && value == r.Values[0]
+	case labelOperatorNotEquals:
+		return !ok || value != r.Values[0]
+	case labelOperatorExists:
+		return ok
+	case labelOperatorNotExists:
+		return !ok
+	case labelOperatorIn:
+		return ok && slices.Contains(r.Values, value)
+	case labelOperatorNotIn:
+		return !ok || !slices.Contains(r.Values, value)
+	}
+	return false
+}
+
+// splitLabelSelector splits a label selector into its requirements,
+// ignoring commas inside of value sets like env in (staging,production).
+func splitLabelSelector(selector string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, selector[start:])
+}
+
+// labelKeyPattern matches label keys: a name with an optional DNS subdomain prefix, e.g. example.com/team.
+var labelKeyPattern = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*/)?[a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?$`)
+
+// labelValuePattern matches label values, which may be empty.
+var labelValuePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?)?$`)
+
+// parseLabelSelector parses a label selector as understood by the Hetzner Cloud API.
+// It supports k=v, k==v, k!=v, k, !k, k in (v1,v2) and k notin (v1,v2).
+func parseLabelSelector(selector string) ([]labelRequirement, error) {
+	var requirements []labelRequirement
+	for _, part := range splitLabelSelector(selector) {
+		part = strings.TrimSpace(part)
+		if part == EmptyString {
+			continue
+		}
+
+		var requirement labelRequirement
+		var err error
+		switch {
+		case strings.Contains(part, "!="):
+			key, value, _ := strings.Cut(part, "!=")
+			requirement = labelRequirement{Key: key, Operator: labelOperatorNotEquals, Values: []string{value}}
+		case strings.Contains(part, "=="):
+			key, value, _ := strings.Cut(part, "==")
+			requirement = labelRequirement{Key: key, Operator: labelOperatorEquals, Values: []string{value}}
+		case strings.Contains(part, "="):
+			key, value, _ := strings.Cut(part, "=")
+			requirement = labelRequirement{Key: key, Operator: labelOperatorEquals, Values: []string{value}}
+		case strings.Contains(part, " notin "):
+			key, values, _ := strings.Cut(part, " notin ")
+			requirement = labelRequirement{Key: key, Operator: labelOperatorNotIn}
+			requirement.Values, err = parseLabelValues(part, values)
+		case strings.Contains(part, " in "):
+			key, values, _ := strings.Cut(part, " in ")
+			requirement = labelRequirement{Key: key, Operator: labelOperatorIn}
+			requirement.Values, err = parseLabelValues(part, values)
+		case strings.HasPrefix(part, "!"):
+			requirement = labelRequirement{Key: strings.TrimPrefix(part, "!"), Operator: labelOperatorNotExists}
+		default:
+			requirement = labelRequirement{Key: part, Operator: labelOperatorExists}
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		requirement.Key = strings.TrimSpace(requirement.Key)
+		if requirement.Key == EmptyString {
+			return nil, fmt.Errorf("invalid label selector requirement %q", part)
+		}
+		if !labelKeyPattern.MatchString(requirement.Key) {
+			return nil, fmt.Errorf("invalid label key %q in label selector requirement %q", requirement.Key, part)
+		}
+		for i, value := range requirement.Values {
+			requirement.Values[i] = strings.TrimSpace(value)
+			if !labelValuePattern.MatchString(requirement.Values[i]) {
+				return nil, fmt.Errorf("invalid label value %q in label selector requirement %q", requirement.Values[i], part)
+			}
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// parseLabelValues parses the value set of an in or notin requirement, e.g. (staging,production).
+func parseLabelValues(part, values string) ([]string, error) {
+	values = strings.TrimSpace(values)
+	if !strings.HasPrefix(values, "(") || !strings.HasSuffix(values, ")") {
+		return nil, fmt.Errorf("invalid label selector requirement %q, the values of in and notin must be enclosed in parentheses", part)
+	}
+	var result []string
+	for _, value := range strings.Split(values[1:len(values)-1], ",") {
+		if value = strings.TrimSpace(value); value != EmptyString {
+			result = append(result, value)
+		}
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("invalid label selector requirement %q, the values of in and notin must not be empty", part)
+	}
+	return result, nil
+}
+
+// LabelScope restricts the resources an agent can change to those matching a label selector.
+type LabelScope struct {
+	// Selector is the label selector resources must match, e.g. team=platform.
+	Selector string
+	// FilterLists hides resources outside the scope from read tools as well.
+	FilterLists bool
+
+	requirements []labelRequirement
+}
+
+// scope is the label scope of the server, nil if the whole project is in scope.
+var scope *LabelScope
+
+// newLabelScope parses the selector and returns the label scope for it.
+func newLabelScope(selector string, filterLists bool) (*LabelScope, error) {
+	requirements, err := parseLabelSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(requirements) == 0 {
+		return nil, fmt.Errorf("empty scope label selector")
+	}
+	return &LabelScope{
+		Selector:     selector,
+		FilterLists:  filterLists,
+		requirements: requirements,
+	}, nil
+}
+
+// Matches reports whether the labels match all requirements of the scope.
+func (s *LabelScope) Matches(labels map[string]string) bool {
+	for _, requirement := range s.requirements {
+		if !requirement.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkScope fails if the resource with the given labels is outside the label scope.
+func checkScope(resourceType string, id int64, labels map[string]string) error {
+	if scope == nil || scope.Matches(labels) {
+		return nil
+	}
+	return fmt.Errorf("%s %d is outside of the label scope %q", resourceType, id, scope.Selector)
+}
+
+// scopeLabels adds the labels required by the label scope to the labels of a resource to create.
+// It fails if the labels contradict the scope.
+func scopeLabels(labels map[string]string) (map[string]string, error) {
+	if scope == nil {
+		return labels, nil
+	}
+
+	scoped := make(map[string]string, len(labels))
+	for key, value := range labels {
+		scoped[key] = value
+	}
+	for _, requirement := range scope.requirements {
+		if requirement.Operator != labelOperatorEquals {
+			continue
+		}
+		if value, ok := scoped[requirement.Key]; ok && value != requirement.Values[0] {
+			return nil, fmt.Errorf("label %s=%s is outside of the label scope %q", requirement.Key, value, scope.Selector)
+		}
+		scoped[requirement.Key] = requirement.Values[0]
+	}
+
+	if !scope.Matches(scoped) {
+		return nil, fmt.Errorf("labels must match the label scope %q", scope.Selector)
+	}
+	return scoped, nil
+}
+
+// scopeLabelSelector narrows down a label selector to resources inside the label scope.
+func scopeLabelSelector(selector string) string {
+	if scope == nil {
+		return selector
+	}
+	if selector == EmptyString {
+		return scope.Selector
+	}
+	return selector + "," + scope.Selector
+}
+
+// visibleInScope reports whether read tools may return a resource with the given labels.
+func visibleInScope(labels map[string]string) bool {
+	return scope == nil || !scope.FilterLists || scope.Matches(labels)
+}
@@ -0,0 +1,254 @@
+package main
+
+import (
+	"maps"
+	"reflect"
+	"testing"
+)
+
+// setTestScope sets the label scope for the duration of the test, no scope if the selector is empty.
+func setTestScope(t *testing.T, selector string) {
+	t.Helper()
+	previous := scope
+	t.Cleanup(func() { scope = previous })
+	if selector == EmptyString {
+		scope = nil
+		return
+	}
+	labelScope, err := newLabelScope(selector, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	scope = labelScope
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     []labelRequirement
+		wantErr  string
+	}{
+		{
+			name:     "equals",
+			selector: "env=prod, team==platform",
+			want: []labelRequirement{
+				{Key: "env", Operator: labelOperatorEquals, Values: []string{"prod"}},
+				{Key: "team", Operator: labelOperatorEquals, Values: []string{"platform"}},
+			},
+		},
+		{
+			name:     "not equals",
+			selector: "env != prod",
+			want:     []labelRequirement{{Key: "env", Operator: labelOperatorNotEquals, Values: []string{"prod"}}},
+		},
+		{
+			name:     "exists and not exists",
+			selector: "example.com/backup,!legacy",
+			want: []labelRequirement{
+				{Key: "example.com/backup", Operator: labelOperatorExists},
+				{Key: "legacy", Operator: labelOperatorNotExists},
+			},
+		},
+		{
+			name:     "in and notin with commas inside the sets",
+			selector: "env in (staging, prod),role notin (db),team=platform",
+			want: []labelRequirement{
+				{Key: "env", Operator: labelOperatorIn, Values: []string{"staging", "prod"}},
+				{Key: "role", Operator: labelOperatorNotIn, Values: []string{"db"}},
+				{Key: "team", Operator: labelOperatorEquals, Values: []string{"platform"}},
+			},
+		},
+		{
+			name:     "empty value",
+			selector: "env=",
+			want:     []labelRequirement{{Key: "env", Operator: labelOperatorEquals, Values: []string{""}}},
+		},
+		{
+			name:     "in without parentheses",
+			selector: "env in prod",
+			wantErr:  `invalid label selector requirement "env in prod", the values of in and notin must be enclosed in parentheses`,
+		},
+		{
+			name:     "notin without parentheses",
+			selector: "env notin prod,staging",
+			wantErr:  `invalid label selector requirement "env notin prod", the values of in and notin must be enclosed in parentheses`,
+		},
+		{
+			name:     "empty set",
+			selector: "env in ()",
+			wantErr:  `invalid label selector requirement "env in ()", the values of in and notin must not be empty`,
+		},
+		{
+			name:     "key with spaces",
+			selector: "my env=prod",
+			wantErr:  `invalid label key "my env" in label selector requirement "my env=prod"`,
+		},
+		{
+			name:     "exists key with spaces",
+			selector: "env prod",
+			wantErr:  `invalid label key "env prod" in label selector requirement "env prod"`,
+		},
+		{
+			name:     "value with spaces",
+			selector: "env=prod eu",
+			wantErr:  `invalid label value "prod eu" in label selector requirement "env=prod eu"`,
+		},
+		{
+			name:     "missing key",
+			selector: "!=prod",
+			wantErr:  `invalid label selector requirement "!=prod"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLabelSelector(tt.selector)
+			if tt.wantErr != EmptyString {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("parseLabelSelector() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseLabelSelector() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseLabelSelector() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabelScopeMatches(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		labels   map[string]string
+		want     bool
+	}{
+		{name: "equals", selector: "env=prod", labels: map[string]string{"env": "prod"}, want: true},
+		{name: "equals other value", selector: "env=prod", labels: map[string]string{"env": "staging"}},
+		{name: "equals missing", selector: "env=prod"},
+		{name: "not equals", selector: "env!=prod", labels: map[string]string{"env": "staging"}, want: true},
+		{name: "not equals missing", selector: "env!=prod", want: true},
+		{name: "not equals same value", selector: "env!=prod", labels: map[string]string{"env": "prod"}},
+		{name: "exists", selector: "env", labels: map[string]string{"env": ""}, want: true},
+		{name: "exists missing", selector: "env"},
+		{name: "not exists", selector: "!env", want: true},
+		{name: "not exists present", selector: "!env", labels: map[string]string{"env": "prod"}},
+		{name: "in", selector: "env in (staging,prod)", labels: map[string]string{"env": "prod"}, want: true},
+		{name: "in other value", selector: "env in (staging,prod)", labels: map[string]string{"env": "dev"}},
+		{name: "in missing", selector: "env in (staging,prod)"},
+		{name: "notin", selector: "env notin (staging,prod)", labels: map[string]string{"env": "dev"}, want: true},
+		{name: "notin missing", selector: "env notin (staging,prod)", want: true},
+		{name: "notin listed value", selector: "env notin (staging,prod)", labels: map[string]string{"env": "staging"}},
+		{name: "all requirements", selector: "env=prod,team", labels: map[string]string{"env": "prod", "team": "platform"}, want: true},
+		{name: "one requirement failing", selector: "env=prod,team", labels: map[string]string{"env": "prod"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			labelScope, err := newLabelScope(tt.selector, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := labelScope.Matches(tt.labels); got != tt.want {
+				t.Errorf("Matches(%v) = %t, want %t", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScopeLabels(t *testing.T) {
+	tests := []struct {
+		name    string
+		scope   string
+		labels  map[string]string
+		want    map[string]string
+		wantErr string
+	}{
+		{
+			name:   "no scope",
+			labels: map[string]string{"env": "staging"},
+			want:   map[string]string{"env": "staging"},
+		},
+		{
+			name:   "adds the scope labels",
+			scope:  "team=platform,env==prod",
+			labels: map[string]string{"role": "web"},
+			want:   map[string]string{"role": "web", "team": "platform", "env": "prod"},
+		},
+		{
+			name:   "keeps matching labels",
+			scope:  "team=platform",
+			labels: map[string]string{"team": "platform"},
+			want:   map[string]string{"team": "platform"},
+		},
+		{
+			name:    "conflicting label",
+			scope:   "team=platform",
+			labels:  map[string]string{"team": "data"},
+			wantErr: `label team=data is outside of the label scope "team=platform"`,
+		},
+		{
+			name:    "labels outside of a set",
+			scope:   "team=platform,env in (staging,prod)",
+			labels:  map[string]string{"env": "dev"},
+			wantErr: `labels must match the label scope "team=platform,env in (staging,prod)"`,
+		},
+		{
+			name:    "missing label of a set",
+			scope:   "env in (staging,prod)",
+			wantErr: `labels must match the label scope "env in (staging,prod)"`,
+		},
+		{
+			name:    "excluded label",
+			scope:   "team=platform,!legacy",
+			labels:  map[string]string{"legacy": "true"},
+			wantErr: `labels must match the label scope "team=platform,!legacy"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestScope(t, tt.scope)
+			labels := maps.Clone(tt.labels)
+			got, err := scopeLabels(labels)
+			if tt.wantErr != EmptyString {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("scopeLabels() error = %v, want %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("scopeLabels() error = %v", err)
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("scopeLabels() = %v, want %v", got, tt.want)
+			}
+			if !maps.Equal(labels, tt.labels) {
+				t.Errorf("scopeLabels() changed the given labels to %v", labels)
+			}
+		})
+	}
+}
+
+func TestScopeLabelSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		scope    string
+		selector string
+		want     string
+	}{
+		{name: "no scope", selector: "env=prod", want: "env=prod"},
+		{name: "no scope and selector", want: ""},
+		{name: "scope only", scope: "team=platform", want: "team=platform"},
+		{name: "narrowed selector", scope: "team=platform", selector: "env in (staging,prod)", want: "env in (staging,prod),team=platform"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTestScope(t, tt.scope)
+			if got := scopeLabelSelector(tt.selector); got != tt.want {
+				t.Errorf("scopeLabelSelector(%q) = %q, want %q", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
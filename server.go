@@ -2,7 +2,8 @@ package main
 
 import (
 	"context"
-	"net"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
@@ -13,58 +14,150 @@ import (
 // It contains the Server ID that is needed to perform the lookup.
 type ServerReadByIDArgs struct {
 	ID int64 `json:"id" jsonschema:"required,description=The server id to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// ServerListArgs represents the arguments for listing Servers.
+// Servers can be filtered by name and status on top of the label selector.
+type ServerListArgs struct {
+	Name   string   `json:"name,omitempty" jsonschema:"description=Only return the server with this name"`
+	Status []string `json:"status,omitempty" jsonschema:"description=Only return servers with one of these statuses (initializing/starting/running/stopping/off/deleting/rebuilding/migrating/unknown)"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
 }
 
 // ServerReadByNameArgs represents the arguments required to read an Server by Name.
 // It contains the Server Name that is needed to perform the lookup.
 type ServerReadByNameArgs struct {
 	Name string `json:"name" jsonschema:"required,description=The server name to be searched"`
+	ProjectArgs
+	OutputArgs
 }
 
-type ServerPublicNet struct {
-	IPv4 net.IP
-	IPv6 net.IP
+// ServerCreateArgs contains the necessary fields to create a new server,
+// including its type, image, location and the resources to attach to it.
+type ServerCreateArgs struct {
+	Name             string            `json:"name" jsonschema:"required,description=The server name"`
+	ServerType       string            `json:"server_type" jsonschema:"required,description=The server type id or name (e.g. cx22)"`
+	Image            string            `json:"image" jsonschema:"required,description=The image id or name to create the server from (e.g. ubuntu-24.04)"`
+	Location         string            `json:"location,omitempty" jsonschema:"description=The location id or name to create the server in (e.g. fsn1)"`
+	SSHKeys          []string          `json:"ssh_keys,omitempty" jsonschema:"description=List of ssh key ids or names to inject into the server"`
+	Networks         []string          `json:"networks,omitempty" jsonschema:"description=List of network ids or names to attach the server to"`
+	Firewalls        []string          `json:"firewalls,omitempty" jsonschema:"description=List of firewall ids or names to apply to the server"`
+	Labels           map[string]string `json:"labels,omitempty" jsonschema:"description=User-defined labels for the server"`
+	UserData         string            `json:"user_data,omitempty" jsonschema:"description=Cloud-Init user data to use during server creation"`
+	StartAfterCreate *bool             `json:"start_after_create,omitempty" jsonschema:"description=Whether to start the server after creation (defaults to true)"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
 }
 
-type ServerType struct {
-	ID   int64
-	Name string
+// ServerNetworkArgs represents the arguments required to attach a Server to a Network.
+type ServerNetworkArgs struct {
+	ID       int64    `json:"id" jsonschema:"required,description=The server id"`
+	Network  string   `json:"network" jsonschema:"required,description=The network id or name"`
+	IP       string   `json:"ip,omitempty" jsonschema:"description=IP address of the server in the network. Assigned automatically if empty"`
+	AliasIPs []string `json:"alias_ips,omitempty" jsonschema:"description=Additional IP addresses of the server in the network"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
 }
 
-type Datacenter struct {
-	ID   int64
-	Name string
+// ServerNetworkDetachArgs represents the arguments required to detach a Server from a Network.
+type ServerNetworkDetachArgs struct {
+	ID      int64  `json:"id" jsonschema:"required,description=The server id"`
+	Network string `json:"network" jsonschema:"required,description=The network id or name"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
 }
 
-type ServerProtection struct {
-	Delete  bool
-	Rebuild bool
+// ServerActionArgs represents the arguments required to run an action on a Server.
+// It contains the Server ID the action is performed on.
+type ServerActionArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The server id"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// ServerRebuildArgs represents the arguments required to rebuild a Server from an Image.
+type ServerRebuildArgs struct {
+	ID    int64  `json:"id" jsonschema:"required,description=The server id"`
+	Image string `json:"image" jsonschema:"required,description=The image id or name to rebuild the server from (e.g. ubuntu-24.04 or the id of a snapshot)"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// ServerRescueArgs represents the arguments required to enable the rescue mode of a Server.
+type ServerRescueArgs struct {
+	ID      int64    `json:"id" jsonschema:"required,description=The server id"`
+	SSHKeys []string `json:"ssh_keys,omitempty" jsonschema:"description=IDs or names of ssh keys to inject into the rescue system"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// ServerChangeTypeArgs represents the arguments required to change the type of a Server.
+type ServerChangeTypeArgs struct {
+	ID          int64  `json:"id" jsonschema:"required,description=The server id"`
+	ServerType  string `json:"server_type" jsonschema:"required,description=The server type id or name to change to (e.g. cx32)"`
+	UpgradeDisk bool   `json:"upgrade_disk,omitempty" jsonschema:"description=Whether to grow the disk to the size of the new server type. A grown disk prevents changing back to server types with a smaller disk"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// ServerISOArgs represents the arguments required to attach an ISO to a Server.
+type ServerISOArgs struct {
+	ID  int64  `json:"id" jsonschema:"required,description=The server id"`
+	ISO string `json:"iso" jsonschema:"required,description=The iso id or name to attach"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+type ServerPublicNet struct {
+	IPv4 string `json:"ipv4" jsonschema:"description=Public IPv4 address of the server"`
+	IPv6 string `json:"ipv6" jsonschema:"description=Public IPv6 network of the server in CIDR notation"`
+}
+
+type ServerPrivateNet struct {
+	Network    *ResourceRef `json:"network" jsonschema:"required,description=The network the server is attached to"`
+	IP         string       `json:"ip" jsonschema:"required,description=IP address of the server in the network"`
+	AliasIPs   []string     `json:"alias_ips" jsonschema:"description=Additional IP addresses of the server in the network"`
+	MACAddress string       `json:"mac_address,omitempty" jsonschema:"description=MAC address of the private interface"`
 }
 
-type Volume struct {
-	ID     int64
-	Name   string
-	Status string
+type ServerProtection struct {
+	Delete  bool `json:"delete" jsonschema:"required,description=Whether the server is protected from deletion"`
+	Rebuild bool `json:"rebuild" jsonschema:"required,description=Whether the server is protected from rebuilds"`
 }
 
 type ServerResponse struct {
-	ID              int64             `json:"id" jsonschema:"required,description=Unique identifier of the server"`
-	Name            string            `json:"name" jsonschema:"required,description=The name of the server"`
-	Status          string            `json:"status" jsonschema:"required,description=Current status of the server"`
-	Created         time.Time         `json:"created" jsonschema:"required,description=Timestamp of when the server was created"`
-	PublicNet       ServerPublicNet   `json:"public_net" jsonschema:"description=Public network IP addresses of the server"`
-	ServerType      ServerType        `json:"server_type" jsonschema:"required,description=Type of the server"`
-	Datacenter      Datacenter        `json:"datacenter" jsonschema:"description=Datacenter where the server is located"`
-	IncludedTraffic uint64            `json:"included_traffic" jsonschema:"required,description=Amount of included traffic in bytes"`
-	OutgoingTraffic uint64            `json:"outgoing_traffic" jsonschema:"required,description=Outgoing traffic in bytes"`
-	IngoingTraffic  uint64            `json:"ingoing_traffic" jsonschema:"required,description=Ingoing traffic in bytes"`
-	BackupWindow    string            `json:"backup_window" jsonschema:"required,description=Time window when backups occur"`
-	RescueEnabled   bool              `json:"rescue_enabled" jsonschema:"required,description=Whether rescue mode is enabled"`
-	Locked          bool              `json:"locked" jsonschema:"required,description=Whether the server is currently locked"`
-	Protection      ServerProtection  `json:"protection" jsonschema:"required,description=Server protection settings"`
-	Labels          map[string]string `json:"labels" jsonschema:"description=User-defined labels for the server"`
-	Volumes         []Volume          `json:"volumes" jsonschema:"description=List of attached volumes"`
-	PrimaryDiskSize int               `json:"primary_disk_size" jsonschema:"description=Size of the primary disk in GB"`
+	ID              int64              `json:"id" jsonschema:"required,description=Unique identifier of the server"`
+	Name            string             `json:"name" jsonschema:"required,description=The name of the server"`
+	Status          string             `json:"status" jsonschema:"required,description=Current status of the server"`
+	Created         time.Time          `json:"created" jsonschema:"required,description=Timestamp of when the server was created"`
+	PublicNet       ServerPublicNet    `json:"public_net" jsonschema:"description=Public network IP addresses of the server"`
+	PrivateNet      []ServerPrivateNet `json:"private_net" jsonschema:"description=Private networks the server is attached to"`
+	ServerType      *ResourceRef       `json:"server_type" jsonschema:"required,description=Type of the server"`
+	Datacenter      *ResourceRef       `json:"datacenter" jsonschema:"description=Datacenter where the server is located"`
+	Image           *ResourceRef       `json:"image" jsonschema:"description=Image the server was created from"`
+	ISO             *ResourceRef       `json:"iso" jsonschema:"description=ISO attached to the server"`
+	IncludedTraffic uint64             `json:"included_traffic" jsonschema:"required,description=Amount of included traffic in bytes"`
+	OutgoingTraffic uint64             `json:"outgoing_traffic" jsonschema:"required,description=Outgoing traffic in bytes"`
+	IngoingTraffic  uint64             `json:"ingoing_traffic" jsonschema:"required,description=Ingoing traffic in bytes"`
+	BackupWindow    string             `json:"backup_window" jsonschema:"required,description=Time window when backups occur"`
+	RescueEnabled   bool               `json:"rescue_enabled" jsonschema:"required,description=Whether rescue mode is enabled"`
+	Locked          bool               `json:"locked" jsonschema:"required,description=Whether the server is currently locked"`
+	Protection      ServerProtection   `json:"protection" jsonschema:"required,description=Server protection settings"`
+	Labels          map[string]string  `json:"labels" jsonschema:"description=User-defined labels for the server"`
+	Volumes         []*ResourceRef     `json:"volumes" jsonschema:"description=List of attached volumes"`
+	PrimaryDiskSize int                `json:"primary_disk_size" jsonschema:"description=Size of the primary disk in GB"`
 }
 
 func toServerResponse(s *hcloud.Server) *ServerResponse {
@@ -72,35 +165,37 @@ func toServerResponse(s *hcloud.Server) *ServerResponse {
 		return nil
 	}
 
-	// Convert volumes from []*Volume to []Volume
-	volumes := make([]Volume, 0, len(s.Volumes))
+	volumes := make([]*ResourceRef, 0, len(s.Volumes))
 	for _, v := range s.Volumes {
 		if v != nil {
-			volumes = append(volumes, Volume{
-				ID:     v.ID,
-				Name:   v.Name,
-				Status: string(v.Status),
-			})
+			volumes = append(volumes, toResourceRef(v.ID, v.Name))
 		}
 	}
 
+	privateNet := make([]ServerPrivateNet, 0, len(s.PrivateNet))
+	for _, p := range s.PrivateNet {
+		privateNet = append(privateNet, toServerPrivateNet(p))
+	}
+
+	var image *ResourceRef
+	if s.Image != nil {
+		image = toResourceRef(s.Image.ID, s.Image.Name)
+	}
+
 	return &ServerResponse{
 		ID:      s.ID,
 		Name:    s.Name,
 		Status:  string(s.Status),
 		Created: s.Created,
 		PublicNet: ServerPublicNet{
-			IPv4: s.PublicNet.IPv4.IP,
-			IPv6: s.PublicNet.IPv6.IP,
-		},
-		ServerType: ServerType{
-			ID:   s.ServerType.ID,
-			Name: s.ServerType.Name,
-		},
-		Datacenter: Datacenter{
-			ID:   s.Datacenter.ID,
-			Name: s.Datacenter.Name,
+			IPv4: ipString(s.PublicNet.IPv4.IP),
+			IPv6: ipNetString(s.PublicNet.IPv6.Network),
 		},
+		PrivateNet:      privateNet,
+		ServerType:      toServerTypeRef(s.ServerType),
+		Datacenter:      toDatacenterRef(s.Datacenter),
+		Image:           image,
+		ISO:             toISORef(s.ISO),
 		IncludedTraffic: s.IncludedTraffic,
 		OutgoingTraffic: s.OutgoingTraffic,
 		IngoingTraffic:  s.IngoingTraffic,
@@ -117,22 +212,418 @@ func toServerResponse(s *hcloud.Server) *ServerResponse {
 	}
 }
 
+func toServerPrivateNet(p hcloud.ServerPrivateNet) ServerPrivateNet {
+	var network *ResourceRef
+	if p.Network != nil {
+		network = toResourceRef(p.Network.ID, p.Network.Name)
+	}
+	aliasIPs := make([]string, 0, len(p.Aliases))
+	for _, alias := range p.Aliases {
+		aliasIPs = append(aliasIPs, ipString(alias))
+	}
+	return ServerPrivateNet{
+		Network:    network,
+		IP:         ipString(p.IP),
+		AliasIPs:   aliasIPs,
+		MACAddress: p.MACAddress,
+	}
+}
+
+// toServerRef collapses a server referenced by another resource to its ID and name.
+func toServerRef(s *hcloud.Server) *ResourceRef {
+	if s == nil {
+		return nil
+	}
+	return toResourceRef(s.ID, s.Name)
+}
+
+type ServerCreateResponse struct {
+	Server       *ServerResponse   `json:"server" jsonschema:"required,description=The created server"`
+	Action       *ActionResponse   `json:"action" jsonschema:"required,description=The action creating the server"`
+	NextActions  []*ActionResponse `json:"next_actions" jsonschema:"description=Actions which run after the server is created, e.g. starting it"`
+	RootPassword string            `json:"root_password,omitempty" jsonschema:"description=The root password of the server, only set if no ssh keys were provided"`
+}
+
+type ServerActionResponse struct {
+	Server       *ServerResponse `json:"server" jsonschema:"description=The server the action was performed on, null if it was deleted"`
+	Action       *ActionResponse `json:"action" jsonschema:"required,description=The triggered action"`
+	RootPassword string          `json:"root_password,omitempty" jsonschema:"description=The new root password, only set by actions generating one"`
+}
+
+type ServerConsoleResponse struct {
+	Action   *ActionResponse `json:"action" jsonschema:"required,description=The action requesting the console"`
+	WSSURL   string          `json:"wss_url" jsonschema:"required,description=URL of the VNC over websocket console, valid for one minute"`
+	Password string          `json:"password" jsonschema:"required,description=Password of the VNC console"`
+}
+
+// getServer retrieves a Server by its ID and fails if the Server does not exist.
+func getServer(ctx context.Context, id int64) (*hcloud.Server, error) {
+	server, _, err := hcloudClient(ctx).Server.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, fmt.Errorf("server %d not found", id)
+	}
+	return server, nil
+}
+
+func toServerCreateOpts(ctx context.Context, args ServerCreateArgs) (hcloud.ServerCreateOpts, error) {
+	opts := hcloud.ServerCreateOpts{
+		Name:             args.Name,
+		ServerType:       &hcloud.ServerType{Name: args.ServerType},
+		Image:            &hcloud.Image{Name: args.Image},
+		UserData:         args.UserData,
+		StartAfterCreate: args.StartAfterCreate,
+	}
+	labels, err := scopeLabels(args.Labels)
+	if err != nil {
+		return opts, err
+	}
+	opts.Labels = labels
+	if args.Location != EmptyString {
+		opts.Location = &hcloud.Location{Name: args.Location}
+	}
+
+	opts.SSHKeys, err = getSSHKeys(ctx, args.SSHKeys)
+	if err != nil {
+		return opts, err
+	}
+
+	for _, idOrName := range args.Networks {
+		network, _, err := hcloudClient(ctx).Network.Get(ctx, idOrName)
+		if err != nil {
+			return opts, err
+		}
+		if network == nil {
+			return opts, fmt.Errorf("network %s not found", idOrName)
+		}
+		if err := checkScope("network", network.ID, network.Labels); err != nil {
+			return opts, err
+		}
+		opts.Networks = append(opts.Networks, network)
+	}
+
+	for _, idOrName := range args.Firewalls {
+		firewall, _, err := hcloudClient(ctx).Firewall.Get(ctx, idOrName)
+		if err != nil {
+			return opts, err
+		}
+		if firewall == nil {
+			return opts, fmt.Errorf("firewall %s not found", idOrName)
+		}
+		if err := checkScope("firewall", firewall.ID, firewall.Labels); err != nil {
+			return opts, err
+		}
+		opts.Firewalls = append(opts.Firewalls, &hcloud.ServerCreateFirewall{Firewall: *firewall})
+	}
+
+	return opts, nil
+}
+
+// planServerCreate validates the options for creating a Server and returns the planned change.
+func planServerCreate(ctx context.Context, opts hcloud.ServerCreateOpts) (*PlannedChange, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	var warnings []string
+
+	existing, _, err := hcloudClient(ctx).Server.GetByName(ctx, opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("server %s already exists", opts.Name)
+	}
+
+	serverType, err := getServerType(ctx, opts.ServerType.Name)
+	if err != nil {
+		return nil, err
+	}
+	if serverType.IsDeprecated() {
+		warnings = append(warnings, fmt.Sprintf("server type %s is deprecated and unavailable after %s", serverType.Name, serverType.UnavailableAfter()))
+	}
+
+	image, err := getImageForArchitecture(ctx, opts.Image.Name, serverType.Architecture)
+	if err != nil {
+		return nil, err
+	}
+	if image.IsDeprecated() {
+		warnings = append(warnings, fmt.Sprintf("image %s is deprecated", image.Name))
+	}
+
+	if opts.Location != nil {
+		location, _, err := hcloudClient(ctx).Location.Get(ctx, opts.Location.Name)
+		if err != nil {
+			return nil, err
+		}
+		if location == nil {
+			return nil, fmt.Errorf("location %s not found", opts.Location.Name)
+		}
+		if !serverTypeAvailableIn(serverType, location) {
+			return nil, fmt.Errorf("server type %s is not available in location %s", serverType.Name, location.Name)
+		}
+	}
+
+	result := &ServerCreateResponse{
+		Server: &ServerResponse{
+			Name:            opts.Name,
+			Status:          string(hcloud.ServerStatusInitializing),
+			Created:         time.Now(),
+			ServerType:      toServerTypeRef(serverType),
+			Labels:          opts.Labels,
+			PrimaryDiskSize: serverType.Disk,
+		},
+		Action:      plannedAction("create_server"),
+		NextActions: []*ActionResponse{},
+	}
+	if opts.StartAfterCreate == nil || *opts.StartAfterCreate {
+		result.NextActions = append(result.NextActions, plannedAction("start_server"))
+	}
+	if len(opts.SSHKeys) == 0 {
+		result.RootPassword = "<generated on creation>"
+	}
+
+	return newPlannedChange("create_server", result, warnings...), nil
+}
+
+// runServerAction looks up a Server and runs the given action on it.
+// If requested, it waits for the action to finish and returns the final state of the Server.
+// In dry-run mode, it returns the Server in the given status it is expected to have after the action.
+// Without a status, the action deletes the Server and no Server is returned.
+func runServerAction(ctx context.Context, args ServerActionArgs, command string, status hcloud.ServerStatus, action func(context.Context, *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)) (*mcpgolang.ToolResponse, error) {
+	return handleResponse(ctx, func() (any, error) {
+		server, err := getServer(ctx, args.ID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkScope("server", server.ID, server.Labels); err != nil {
+			return nil, err
+		}
+
+		if args.isDryRun() {
+			var warnings []string
+			if server.Locked {
+				warnings = append(warnings, fmt.Sprintf("server %d is locked by another action", server.ID))
+			}
+			result := &ServerActionResponse{
+				Action: plannedAction(command, ActionResource{ID: server.ID, Type: string(hcloud.ActionResourceTypeServer)}),
+			}
+			if status == EmptyString {
+				if server.Protection.Delete {
+					return nil, fmt.Errorf("server %d is protected against deletion", server.ID)
+				}
+			} else {
+				if server.Status == status {
+					warnings = append(warnings, fmt.Sprintf("server %d is already %s", server.ID, status))
+				}
+				result.Server = toServerResponse(server)
+				result.Server.Status = string(status)
+			}
+			return newPlannedChange(command, result, warnings...), nil
+		}
+
+		result, _, err := action(ctx, server)
+		if err != nil {
+			return nil, err
+		}
+		recordActions(ctx, result)
+		if args.Wait {
+			result, err = waitForAction(ctx, result)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if status == EmptyString {
+			// The Server is deleted
+			server = nil
+		} else if args.Wait {
+			server, _, err = hcloudClient(ctx).Server.GetByID(ctx, server.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &ServerActionResponse{
+			Server: toServerResponse(server),
+			Action: toActionResponse(result),
+		}, nil
+	})
+}
+
+// runCheckedServerAction looks up a Server and runs the given action on it.
+// check validates the action in advance and fills in the result it is expected to have, starting from
+// the current state of the Server. In dry-run mode, the expected result is returned as planned change.
+// action returns the triggered Action and the root password if the action generates a new one.
+func runCheckedServerAction(ctx context.Context, id int64, write WriteArgs, command string,
+	check func(context.Context, *hcloud.Server, *ServerActionResponse) ([]string, error),
+	action func(context.Context, *hcloud.Server) (*hcloud.Action, string, error),
+) (*mcpgolang.ToolResponse, error) {
+	return handleResponse(ctx, func() (any, error) {
+		server, err := getServer(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkScope("server", server.ID, server.Labels); err != nil {
+			return nil, err
+		}
+		expected := &ServerActionResponse{
+			Server: toServerResponse(server),
+			Action: plannedAction(command, ActionResource{ID: server.ID, Type: string(hcloud.ActionResourceTypeServer)}),
+		}
+		warnings, err := check(ctx, server, expected)
+		if err != nil {
+			return nil, err
+		}
+
+		if write.isDryRun() {
+			if server.Locked {
+				warnings = append(warnings, fmt.Sprintf("server %d is locked by another action", server.ID))
+			}
+			return newPlannedChange(command, expected, warnings...), nil
+		}
+
+		result, rootPassword, err := action(ctx, server)
+		if err != nil {
+			return nil, err
+		}
+		recordActions(ctx, result)
+		if write.Wait {
+			result, err = waitForAction(ctx, result)
+			if err != nil {
+				return nil, err
+			}
+			server, err = getServer(ctx, server.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &ServerActionResponse{
+			Server:       toServerResponse(server),
+			Action:       toActionResponse(result),
+			RootPassword: rootPassword,
+		}, nil
+	})
+}
+
+// toServerAttachToNetworkOpts validates the IP addresses of a Server in a Network. They must be inside
+// a subnet of the network in the network zone of the server.
+func toServerAttachToNetworkOpts(server *hcloud.Server, network *hcloud.Network, args ServerNetworkArgs) (hcloud.ServerAttachToNetworkOpts, error) {
+	opts := hcloud.ServerAttachToNetworkOpts{Network: network}
+
+	var networkZone hcloud.NetworkZone
+	if server.Datacenter != nil && server.Datacenter.Location != nil {
+		networkZone = server.Datacenter.Location.NetworkZone
+	}
+	subnets := zoneSubnets(network, networkZone)
+	if len(subnets) == 0 {
+		return opts, fmt.Errorf("network %d has no subnet in the network zone %s of server %d", network.ID, networkZone, server.ID)
+	}
+
+	if args.IP != EmptyString {
+		ip, err := parseSubnetIP("server IP", args.IP, network, networkZone, subnets)
+		if err != nil {
+			return opts, err
+		}
+		opts.IP = ip
+	}
+	for _, value := range args.AliasIPs {
+		alias, err := parseSubnetIP("alias IP", value, network, networkZone, subnets)
+		if err != nil {
+			return opts, err
+		}
+		if alias.Equal(opts.IP) || slices.ContainsFunc(opts.AliasIPs, alias.Equal) {
+			return opts, fmt.Errorf("IP %s is given more than once", alias)
+		}
+		opts.AliasIPs = append(opts.AliasIPs, alias)
+	}
+	return opts, nil
+}
+
+// runServerNetworkAction looks up a Server and a Network and runs the given action on them.
+// check validates the change in advance and returns the Server it is expected to result in,
+// which is returned as planned change in dry-run mode.
+func runServerNetworkAction(ctx context.Context, id int64, networkIDOrName string, write WriteArgs, command string,
+	check func(*hcloud.Server, *hcloud.Network, *ServerResponse) error,
+	action func(context.Context, *hcloud.Server, *hcloud.Network) (*hcloud.Action, error),
+) (*mcpgolang.ToolResponse, error) {
+	return handleResponse(ctx, func() (any, error) {
+		server, err := getServer(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkScope("server", server.ID, server.Labels); err != nil {
+			return nil, err
+		}
+		network, err := getNetwork(ctx, networkIDOrName)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkScope("network", network.ID, network.Labels); err != nil {
+			return nil, err
+		}
+		expected := toServerResponse(server)
+		if err := check(server, network, expected); err != nil {
+			return nil, err
+		}
+
+		if write.isDryRun() {
+			return newPlannedChange(command, &ServerActionResponse{
+				Server: expected,
+				Action: plannedAction(command,
+					ActionResource{ID: server.ID, Type: string(hcloud.ActionResourceTypeServer)},
+					ActionResource{ID: network.ID, Type: "network"}),
+			}), nil
+		}
+
+		result, err := action(ctx, server, network)
+		if err != nil {
+			return nil, err
+		}
+		recordActions(ctx, result)
+		if write.Wait {
+			result, err = waitForAction(ctx, result)
+			if err != nil {
+				return nil, err
+			}
+			server, err = getServer(ctx, server.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return &ServerActionResponse{
+			Server: toServerResponse(server),
+			Action: toActionResponse(result),
+		}, nil
+	})
+}
+
 // ServerTools
 var serverTools = []Tool{
 	{
 		Name:        "get_all_servers",
-		Description: "Returns all Servers objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*ServerResponse, error) {
-				result, err := client.Server.All(context.Background())
+		Description: "Returns a page of Servers, optionally filtered by name, status and label selector.",
+		Handler: func(ctx context.Context, args ServerListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*ServerResponse], error) {
+				listOpts, err := args.listOpts()
 				if err != nil {
 					return nil, err
 				}
-				var filtered []*ServerResponse
-				for _, s := range result {
-					filtered = append(filtered, toServerResponse(s))
+				opts := hcloud.ServerListOpts{
+					ListOpts: listOpts,
+					Name:     args.Name,
+				}
+				for _, status := range args.Status {
+					opts.Status = append(opts.Status, hcloud.ServerStatus(status))
 				}
-				return filtered, nil
+				result, resp, err := hcloudClient(ctx).Server.List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				items := make([]*ServerResponse, 0, len(result))
+				for _, item := range result {
+					items = append(items, toServerResponse(item))
+				}
+				return newListResponse(items, resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -140,12 +631,15 @@ var serverTools = []Tool{
 	{
 		Name:        "get_a_server_by_id",
 		Description: "Retrieves a Server by its ID. If the Server does not exist, nil is returned.",
-		Handler: func(args ServerReadByIDArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*ServerResponse, error) {
-				result, _, err := client.Server.GetByID(context.Background(), args.ID)
+		Handler: func(ctx context.Context, args ServerReadByIDArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ServerResponse, error) {
+				result, _, err := hcloudClient(ctx).Server.GetByID(ctx, args.ID)
 				if err != nil {
 					return nil, err
 				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
 				return toServerResponse(result), nil
 			})
 		},
@@ -154,15 +648,449 @@ var serverTools = []Tool{
 	{
 		Name:        "get_a_server_by_name",
 		Description: "Retrieves a Server by its Name. If the Server does not exist, nil is returned.",
-		Handler: func(args ServerReadByNameArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*ServerResponse, error) {
-				result, _, err := client.Server.GetByName(context.Background(), args.Name)
+		Handler: func(ctx context.Context, args ServerReadByNameArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ServerResponse, error) {
+				result, _, err := hcloudClient(ctx).Server.GetByName(ctx, args.Name)
 				if err != nil {
 					return nil, err
 				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
 				return toServerResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
 	},
+	{
+		Name:        "create_a_server",
+		Description: "Creates a new Server. The root password is only returned if no SSH keys were provided.",
+		Handler: func(ctx context.Context, args ServerCreateArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				opts, err := toServerCreateOpts(ctx, args)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					return planServerCreate(ctx, opts)
+				}
+				result, _, err := hcloudClient(ctx).Server.Create(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				recordActions(ctx, append([]*hcloud.Action{result.Action}, result.NextActions...)...)
+				if args.Wait {
+					actions, err := waitForActions(ctx, append([]*hcloud.Action{result.Action}, result.NextActions...)...)
+					if err != nil {
+						return nil, err
+					}
+					result.Action, result.NextActions = actions[0], actions[1:]
+					result.Server, _, err = hcloudClient(ctx).Server.GetByID(ctx, result.Server.ID)
+					if err != nil {
+						return nil, err
+					}
+				}
+				return &ServerCreateResponse{
+					Server:       toServerResponse(result.Server),
+					Action:       toActionResponse(result.Action),
+					NextActions:  toActionResponseList(result.NextActions),
+					RootPassword: result.RootPassword,
+				}, nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_a_server",
+		Description: "Deletes a Server. This immediately removes the server and all of its data, it cannot be undone.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runServerAction(ctx, args, "delete_server", EmptyString, func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, *hcloud.Response, error) {
+				result, resp, err := hcloudClient(ctx).Server.DeleteWithResult(ctx, server)
+				if err != nil {
+					return nil, resp, err
+				}
+				return result.Action, resp, nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "power_on_a_server",
+		Description: "Starts a Server by turning its power on.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runServerAction(ctx, args, "start_server", hcloud.ServerStatusRunning, hcloudClient(ctx).Server.Poweron)
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "power_off_a_server",
+		Description: "Cuts power to a Server. This forcefully stops it without giving the operating system time to gracefully stop and may lead to data loss.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runServerAction(ctx, args, "stop_server", hcloud.ServerStatusOff, hcloudClient(ctx).Server.Poweroff)
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "reboot_a_server",
+		Description: "Reboots a Server gracefully by sending an ACPI request. The operating system must support ACPI and react to the request.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runServerAction(ctx, args, "reboot_server", hcloud.ServerStatusRunning, hcloudClient(ctx).Server.Reboot)
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "reset_a_server",
+		Description: "Cuts power to a Server and starts it again. This forcefully restarts it and may lead to data loss.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runServerAction(ctx, args, "reset_server", hcloud.ServerStatusRunning, hcloudClient(ctx).Server.Reset)
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "shutdown_a_server",
+		Description: "Shuts down a Server gracefully by sending an ACPI shutdown request. The operating system must support ACPI and react to the request.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runServerAction(ctx, args, "shutdown_server", hcloud.ServerStatusOff, hcloudClient(ctx).Server.Shutdown)
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "attach_server_to_network",
+		Description: "Attaches a Server to a Network. The network needs a subnet in the network zone of the server. IP and alias IPs must be inside such a subnet.",
+		Handler: func(ctx context.Context, args ServerNetworkArgs) (*mcpgolang.ToolResponse, error) {
+			var opts hcloud.ServerAttachToNetworkOpts
+			return runServerNetworkAction(ctx, args.ID, args.Network, args.WriteArgs, "attach_to_network",
+				func(server *hcloud.Server, network *hcloud.Network, expected *ServerResponse) error {
+					for _, p := range server.PrivateNet {
+						if p.Network != nil && p.Network.ID == network.ID {
+							return fmt.Errorf("server %d is already attached to network %d", server.ID, network.ID)
+						}
+					}
+					var err error
+					opts, err = toServerAttachToNetworkOpts(server, network, args)
+					if err != nil {
+						return err
+					}
+					expected.PrivateNet = append(expected.PrivateNet, toServerPrivateNet(hcloud.ServerPrivateNet{
+						Network: network,
+						IP:      opts.IP,
+						Aliases: opts.AliasIPs,
+					}))
+					return nil
+				},
+				func(ctx context.Context, server *hcloud.Server, network *hcloud.Network) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Server.AttachToNetwork(ctx, server, opts)
+					return result, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "detach_server_from_network",
+		Description: "Detaches a Server from a Network.",
+		Handler: func(ctx context.Context, args ServerNetworkDetachArgs) (*mcpgolang.ToolResponse, error) {
+			return runServerNetworkAction(ctx, args.ID, args.Network, args.WriteArgs, "detach_from_network",
+				func(server *hcloud.Server, network *hcloud.Network, expected *ServerResponse) error {
+					i := slices.IndexFunc(expected.PrivateNet, func(p ServerPrivateNet) bool {
+						return p.Network != nil && p.Network.ID == network.ID
+					})
+					if i < 0 {
+						return fmt.Errorf("server %d is not attached to network %d", server.ID, network.ID)
+					}
+					expected.PrivateNet = slices.Delete(expected.PrivateNet, i, i+1)
+					return nil
+				},
+				func(ctx context.Context, server *hcloud.Server, network *hcloud.Network) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Server.DetachFromNetwork(ctx, server, hcloud.ServerDetachFromNetworkOpts{Network: network})
+					return result, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "rebuild_a_server",
+		Description: "Rebuilds a Server from an Image. The disk is overwritten with the image and all data on it is lost, attached volumes are kept. A new root password is returned if the image generates one.",
+		Handler: func(ctx context.Context, args ServerRebuildArgs) (*mcpgolang.ToolResponse, error) {
+			var image *hcloud.Image
+			return runCheckedServerAction(ctx, args.ID, args.WriteArgs, "rebuild_server",
+				func(ctx context.Context, server *hcloud.Server, expected *ServerActionResponse) ([]string, error) {
+					if server.Protection.Rebuild {
+						return nil, fmt.Errorf("server %d is protected against rebuilds", server.ID)
+					}
+					var err error
+					image, err = getImageForArchitecture(ctx, args.Image, server.ServerType.Architecture)
+					if err != nil {
+						return nil, err
+					}
+					if image.Status != hcloud.ImageStatusAvailable {
+						return nil, fmt.Errorf("image %d is %s, only available images can be used", image.ID, image.Status)
+					}
+					if int(image.DiskSize) > server.PrimaryDiskSize {
+						return nil, fmt.Errorf("image %d needs a disk of %.0f GB, server %d only has %d GB", image.ID, image.DiskSize, server.ID, server.PrimaryDiskSize)
+					}
+					warnings := []string{fmt.Sprintf("all data on the disk of server %d is replaced by image %d", server.ID, image.ID)}
+					if image.IsDeprecated() {
+						warnings = append(warnings, fmt.Sprintf("image %s is deprecated", image.Name))
+					}
+					expected.Server.Image = toResourceRef(image.ID, image.Name)
+					return warnings, nil
+				},
+				func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, string, error) {
+					result, _, err := hcloudClient(ctx).Server.RebuildWithResult(ctx, server, hcloud.ServerRebuildOpts{Image: image})
+					return result.Action, result.RootPassword, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "enable_rescue_mode",
+		Description: "Enables the rescue mode of a Server. The server boots into the rescue system on its next reboot, which is not triggered. Returns the root password of the rescue system.",
+		Handler: func(ctx context.Context, args ServerRescueArgs) (*mcpgolang.ToolResponse, error) {
+			var sshKeys []*hcloud.SSHKey
+			return runCheckedServerAction(ctx, args.ID, args.WriteArgs, "enable_rescue",
+				func(ctx context.Context, server *hcloud.Server, expected *ServerActionResponse) ([]string, error) {
+					if server.RescueEnabled {
+						return nil, fmt.Errorf("rescue mode of server %d is already enabled", server.ID)
+					}
+					var err error
+					sshKeys, err = getSSHKeys(ctx, args.SSHKeys)
+					if err != nil {
+						return nil, err
+					}
+					var warnings []string
+					if server.Status == hcloud.ServerStatusRunning {
+						warnings = append(warnings, fmt.Sprintf("server %d keeps running its operating system until it is rebooted, e.g. with reset_a_server", server.ID))
+					}
+					expected.Server.RescueEnabled = true
+					expected.RootPassword = "<generated on enabling rescue mode>"
+					return warnings, nil
+				},
+				func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, string, error) {
+					result, _, err := hcloudClient(ctx).Server.EnableRescue(ctx, server, hcloud.ServerEnableRescueOpts{
+						Type:    hcloud.ServerRescueTypeLinux64,
+						SSHKeys: sshKeys,
+					})
+					return result.Action, result.RootPassword, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "disable_rescue_mode",
+		Description: "Disables the rescue mode of a Server. A server running the rescue system keeps running it until it is rebooted.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runCheckedServerAction(ctx, args.ID, args.WriteArgs, "disable_rescue",
+				func(ctx context.Context, server *hcloud.Server, expected *ServerActionResponse) ([]string, error) {
+					if !server.RescueEnabled {
+						return nil, fmt.Errorf("rescue mode of server %d is not enabled", server.ID)
+					}
+					expected.Server.RescueEnabled = false
+					return nil, nil
+				},
+				func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, string, error) {
+					result, _, err := hcloudClient(ctx).Server.DisableRescue(ctx, server)
+					return result, EmptyString, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "change_server_type",
+		Description: "Changes the type of a stopped Server, e.g. to give it more CPU and memory. With upgrade_disk, the disk grows as well, which prevents changing back to server types with a smaller disk.",
+		Handler: func(ctx context.Context, args ServerChangeTypeArgs) (*mcpgolang.ToolResponse, error) {
+			var serverType *hcloud.ServerType
+			return runCheckedServerAction(ctx, args.ID, args.WriteArgs, "change_server_type",
+				func(ctx context.Context, server *hcloud.Server, expected *ServerActionResponse) ([]string, error) {
+					if err := checkServerOff(server, "change its type"); err != nil {
+						return nil, err
+					}
+					var err error
+					serverType, err = getServerType(ctx, args.ServerType)
+					if err != nil {
+						return nil, err
+					}
+					if serverType.ID == server.ServerType.ID {
+						return nil, fmt.Errorf("server %d already has server type %s", server.ID, serverType.Name)
+					}
+					if serverType.Architecture != server.ServerType.Architecture {
+						return nil, fmt.Errorf("server type %s has architecture %s, server %d has %s", serverType.Name, serverType.Architecture, server.ID, server.ServerType.Architecture)
+					}
+					if serverType.Disk < server.PrimaryDiskSize {
+						return nil, fmt.Errorf("server type %s has a %d GB disk, server %d already has %d GB and disks cannot shrink", serverType.Name, serverType.Disk, server.ID, server.PrimaryDiskSize)
+					}
+					if server.Datacenter != nil && !serverTypeAvailableIn(serverType, server.Datacenter.Location) {
+						return nil, fmt.Errorf("server type %s is not available in location %s", serverType.Name, server.Datacenter.Location.Name)
+					}
+
+					var warnings []string
+					if serverType.IsDeprecated() {
+						warnings = append(warnings, fmt.Sprintf("server type %s is deprecated and unavailable after %s", serverType.Name, serverType.UnavailableAfter()))
+					}
+					expected.Server.ServerType = toServerTypeRef(serverType)
+					if args.UpgradeDisk && serverType.Disk > server.PrimaryDiskSize {
+						warnings = append(warnings, fmt.Sprintf("the disk of server %d grows to %d GB, it can no longer change to server types with a smaller disk", server.ID, serverType.Disk))
+						expected.Server.PrimaryDiskSize = serverType.Disk
+					}
+					return warnings, nil
+				},
+				func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, string, error) {
+					result, _, err := hcloudClient(ctx).Server.ChangeType(ctx, server, hcloud.ServerChangeTypeOpts{
+						ServerType:  serverType,
+						UpgradeDisk: args.UpgradeDisk,
+					})
+					return result, EmptyString, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "attach_iso_to_server",
+		Description: "Attaches an ISO to a Server, replacing an attached ISO. The server boots from the ISO on its next reboot, which is not triggered.",
+		Handler: func(ctx context.Context, args ServerISOArgs) (*mcpgolang.ToolResponse, error) {
+			var iso *hcloud.ISO
+			return runCheckedServerAction(ctx, args.ID, args.WriteArgs, "attach_iso",
+				func(ctx context.Context, server *hcloud.Server, expected *ServerActionResponse) ([]string, error) {
+					var err error
+					iso, err = getISO(ctx, args.ISO)
+					if err != nil {
+						return nil, err
+					}
+					if iso.Architecture != nil && *iso.Architecture != server.ServerType.Architecture {
+						return nil, fmt.Errorf("iso %s has architecture %s, server %d has %s", iso.Name, *iso.Architecture, server.ID, server.ServerType.Architecture)
+					}
+					var warnings []string
+					if server.ISO != nil {
+						if server.ISO.ID == iso.ID {
+							return nil, fmt.Errorf("iso %s is already attached to server %d", iso.Name, server.ID)
+						}
+						warnings = append(warnings, fmt.Sprintf("iso %s attached to server %d is replaced", server.ISO.Name, server.ID))
+					}
+					if iso.IsDeprecated() {
+						warnings = append(warnings, fmt.Sprintf("iso %s is deprecated", iso.Name))
+					}
+					expected.Server.ISO = toISORef(iso)
+					return warnings, nil
+				},
+				func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, string, error) {
+					result, _, err := hcloudClient(ctx).Server.AttachISO(ctx, server, iso)
+					return result, EmptyString, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "detach_iso_from_server",
+		Description: "Detaches the ISO attached to a Server.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runCheckedServerAction(ctx, args.ID, args.WriteArgs, "detach_iso",
+				func(ctx context.Context, server *hcloud.Server, expected *ServerActionResponse) ([]string, error) {
+					if server.ISO == nil {
+						return nil, fmt.Errorf("server %d has no iso attached", server.ID)
+					}
+					expected.Server.ISO = nil
+					return nil, nil
+				},
+				func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, string, error) {
+					result, _, err := hcloudClient(ctx).Server.DetachISO(ctx, server)
+					return result, EmptyString, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "enable_server_backup",
+		Description: "Enables daily backups of a Server in a backup window assigned by Hetzner. Backups cost 20% of the server price.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runCheckedServerAction(ctx, args.ID, args.WriteArgs, "enable_backup",
+				func(ctx context.Context, server *hcloud.Server, expected *ServerActionResponse) ([]string, error) {
+					if server.BackupWindow != EmptyString {
+						return nil, fmt.Errorf("backups of server %d are already enabled", server.ID)
+					}
+					expected.Server.BackupWindow = "<assigned on enabling backups>"
+					return []string{fmt.Sprintf("backups of server %d cost 20%% of its price", server.ID)}, nil
+				},
+				func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, string, error) {
+					result, _, err := hcloudClient(ctx).Server.EnableBackup(ctx, server, EmptyString)
+					return result, EmptyString, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "disable_server_backup",
+		Description: "Disables the backups of a Server. All existing backups of the server are deleted, it cannot be undone.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runCheckedServerAction(ctx, args.ID, args.WriteArgs, "disable_backup",
+				func(ctx context.Context, server *hcloud.Server, expected *ServerActionResponse) ([]string, error) {
+					if server.BackupWindow == EmptyString {
+						return nil, fmt.Errorf("backups of server %d are not enabled", server.ID)
+					}
+					expected.Server.BackupWindow = EmptyString
+					return []string{fmt.Sprintf("all backups of server %d are deleted", server.ID)}, nil
+				},
+				func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, string, error) {
+					result, _, err := hcloudClient(ctx).Server.DisableBackup(ctx, server)
+					return result, EmptyString, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "reset_server_password",
+		Description: "Resets the root password of a running Server and returns the new password. The qemu guest agent must be running on the server.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runCheckedServerAction(ctx, args.ID, args.WriteArgs, "reset_password",
+				func(ctx context.Context, server *hcloud.Server, expected *ServerActionResponse) ([]string, error) {
+					if server.Status != hcloud.ServerStatusRunning {
+						return nil, fmt.Errorf("server %d is %s, its root password can only be reset while it is running", server.ID, server.Status)
+					}
+					expected.RootPassword = "<generated on reset>"
+					return nil, nil
+				},
+				func(ctx context.Context, server *hcloud.Server) (*hcloud.Action, string, error) {
+					result, _, err := hcloudClient(ctx).Server.ResetPassword(ctx, server)
+					return result.Action, result.RootPassword, err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "request_server_console",
+		Description: "Requests a VNC console of a Server. Returns the websocket URL and the password of the console, the URL is valid for one minute.",
+		Handler: func(ctx context.Context, args ServerActionArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				server, err := getServer(ctx, args.ID)
+				if err != nil {
+					return nil, err
+				}
+				if err := checkScope("server", server.ID, server.Labels); err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					return newPlannedChange("request_console", &ServerConsoleResponse{
+						Action:   plannedAction("request_console", ActionResource{ID: server.ID, Type: string(hcloud.ActionResourceTypeServer)}),
+						WSSURL:   "<generated on request>",
+						Password: "<generated on request>",
+					}), nil
+				}
+				result, _, err := hcloudClient(ctx).Server.RequestConsole(ctx, server)
+				if err != nil {
+					return nil, err
+				}
+				recordActions(ctx, result.Action)
+				if args.Wait {
+					result.Action, err = waitForAction(ctx, result.Action)
+					if err != nil {
+						return nil, err
+					}
+				}
+				return &ServerConsoleResponse{
+					Action:   toActionResponse(result.Action),
+					WSSURL:   result.WSSURL,
+					Password: result.Password,
+				}, nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
 }
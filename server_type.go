@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	mcpgolang "github.com/metoro-io/mcp-golang"
@@ -11,6 +12,80 @@ import (
 // It contains the ServerType ID or Name that is needed to perform the lookup.
 type ServerTypeReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The Server Type id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+type ServerTypeResponse struct {
+	ID           int64                     `json:"id" jsonschema:"required,description=Unique identifier of the server type"`
+	Name         string                    `json:"name" jsonschema:"required,description=The name of the server type (e.g. cx22)"`
+	Description  string                    `json:"description" jsonschema:"description=The description of the server type"`
+	Cores        int                       `json:"cores" jsonschema:"required,description=Number of CPU cores"`
+	Memory       float32                   `json:"memory" jsonschema:"required,description=Memory in GB"`
+	Disk         int                       `json:"disk" jsonschema:"required,description=Disk size in GB"`
+	StorageType  string                    `json:"storage_type" jsonschema:"description=Type of the disk either of local or network"`
+	CPUType      string                    `json:"cpu_type" jsonschema:"description=Type of the CPU either of shared or dedicated"`
+	Architecture string                    `json:"architecture" jsonschema:"description=CPU architecture either of x86 or arm"`
+	Prices       []LocationPricingResponse `json:"prices" jsonschema:"description=Prices of the server type per location"`
+	Deprecation  *DeprecationResponse      `json:"deprecation,omitempty" jsonschema:"description=Deprecation of the server type if it is deprecated"`
+}
+
+func toServerTypeResponse(s *hcloud.ServerType) *ServerTypeResponse {
+	if s == nil {
+		return nil
+	}
+
+	return &ServerTypeResponse{
+		ID:           s.ID,
+		Name:         s.Name,
+		Description:  s.Description,
+		Cores:        s.Cores,
+		Memory:       s.Memory,
+		Disk:         s.Disk,
+		StorageType:  string(s.StorageType),
+		CPUType:      string(s.CPUType),
+		Architecture: string(s.Architecture),
+		Prices:       toServerTypePrices(s.Pricings),
+		Deprecation:  toDeprecationResponse(s.DeprecatableResource.Deprecation),
+	}
+}
+
+func toServerTypePrices(pricings []hcloud.ServerTypeLocationPricing) []LocationPricingResponse {
+	prices := make([]LocationPricingResponse, 0, len(pricings))
+	for _, p := range pricings {
+		prices = append(prices, toLocationPricingResponse(p.Location, p.Hourly, p.Monthly, p.IncludedTraffic, p.PerTBTraffic))
+	}
+	return prices
+}
+
+func toServerTypeRef(s *hcloud.ServerType) *ResourceRef {
+	if s == nil {
+		return nil
+	}
+	return toResourceRef(s.ID, s.Name)
+}
+
+// getServerType retrieves a Server Type by its ID or name and fails if the Server Type does not exist.
+func getServerType(ctx context.Context, idOrName string) (*hcloud.ServerType, error) {
+	serverType, _, err := hcloudClient(ctx).ServerType.Get(ctx, idOrName)
+	if err != nil {
+		return nil, err
+	}
+	if serverType == nil {
+		return nil, fmt.Errorf("server type %s not found", idOrName)
+	}
+	return serverType, nil
+}
+
+// serverTypeAvailableIn reports whether Servers of the Server Type can run in the Location.
+// The locations of the pricings only carry their name.
+func serverTypeAvailableIn(serverType *hcloud.ServerType, location *hcloud.Location) bool {
+	for _, pricing := range serverType.Pricings {
+		if pricing.Location != nil && location != nil && pricing.Location.Name == location.Name {
+			return true
+		}
+	}
+	return false
 }
 
 // ServerTypeTools
@@ -18,10 +93,13 @@ var serverTypeTools = []Tool{
 	{
 		Name:        "get_all_server_types",
 		Description: "Returns all ServerTypes objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.ServerType, error) {
-				result, err := client.ServerType.All(context.Background())
-				return result, err
+		Handler: func(ctx context.Context, _ NoArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() ([]*ServerTypeResponse, error) {
+				result, err := hcloudClient(ctx).ServerType.All(ctx)
+				if err != nil {
+					return nil, err
+				}
+				return toResponseList(result, toServerTypeResponse), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -29,10 +107,13 @@ var serverTypeTools = []Tool{
 	{
 		Name:        "get_a_server_type_by_id_or_name",
 		Description: "Retrieves a ServerType by its ID or Name. Get retrieves a server type by its ID if the input can be parsed as an integer, otherwise it retrieves a server type by its name. If the server type does not exist, nil is returned.",
-		Handler: func(args ServerTypeReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.ServerType, error) {
-				result, _, err := client.ServerType.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args ServerTypeReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ServerTypeResponse, error) {
+				result, _, err := hcloudClient(ctx).ServerType.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				return toServerTypeResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -0,0 +1,502 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	mcpgolang "github.com/metoro-io/mcp-golang"
+)
+
+// Labels of the snapshots created by snapshot policies. Only snapshots carrying the policy label are pruned.
+const (
+	SnapshotPolicyLabel = "mcp-hetzner/snapshot-policy"
+	SnapshotServerLabel = "mcp-hetzner/server"
+)
+
+// minSnapshotSchedule is the shortest schedule of a snapshot policy.
+const minSnapshotSchedule = time.Hour
+
+// snapshotSchedulerInterval is how often the scheduler checks whether snapshot policies are due.
+const snapshotSchedulerInterval = time.Minute
+
+// snapshotPolicyNamePattern matches the names of snapshot policies, which are used as label values.
+var snapshotPolicyNamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9._-]{0,61}[a-zA-Z0-9])?$`)
+
+// SnapshotPolicy represents a snapshot retention policy in the configuration file.
+// Running a policy snapshots every Server matching the label selector and prunes the snapshots of the
+// policy which are neither among the last ones nor the newest of a kept day or week.
+type SnapshotPolicy struct {
+	Name          string        `yaml:"name"`
+	Project       string        `yaml:"project"`
+	LabelSelector string        `yaml:"label_selector"`
+	Schedule      time.Duration `yaml:"schedule"`
+	KeepLast      int           `yaml:"keep_last"`
+	KeepDaily     int           `yaml:"keep_daily"`
+	KeepWeekly    int           `yaml:"keep_weekly"`
+}
+
+// snapshotPolicies are the snapshot policies of the server.
+var snapshotPolicies []SnapshotPolicy
+
+// snapshotPolicyMu prevents running snapshot policies concurrently, e.g. by the scheduler and a tool.
+var snapshotPolicyMu sync.Mutex
+
+// snapshotFailureKey identifies the snapshots of a Server by a policy.
+type snapshotFailureKey struct {
+	policy string
+	server int64
+}
+
+// snapshotFailure remembers the failed snapshot attempts of a Server by a policy since its last snapshot.
+type snapshotFailure struct {
+	last     time.Time
+	attempts int
+}
+
+// snapshotFailures are the failed snapshot attempts, guarded by snapshotPolicyMu.
+// Scheduled runs back off from retrying them instead of failing on every tick.
+var snapshotFailures = make(map[snapshotFailureKey]*snapshotFailure)
+
+// backoff returns how long scheduled runs wait after the last failed attempt before trying again:
+// the scheduler interval, doubled for every further failed attempt, but at most the schedule.
+func (f *snapshotFailure) backoff(schedule time.Duration) time.Duration {
+	return min(snapshotSchedulerInterval<<min(f.attempts-1, 16), schedule)
+}
+
+// Validate checks the policy and reports all problems at once.
+func (p SnapshotPolicy) Validate() error {
+	var errs []error
+
+	if !snapshotPolicyNamePattern.MatchString(p.Name) {
+		errs = append(errs, fmt.Errorf("invalid name %q, must be a label value of at most 63 alphanumeric characters, '-', '_' or '.'", p.Name))
+	}
+	if p.LabelSelector == EmptyString {
+		errs = append(errs, fmt.Errorf("missing label selector, policies never apply to all servers"))
+	} else if _, err := parseLabelSelector(p.LabelSelector); err != nil {
+		errs = append(errs, fmt.Errorf("invalid label selector: %w", err))
+	}
+	if p.Schedule != 0 && p.Schedule < minSnapshotSchedule {
+		errs = append(errs, fmt.Errorf("schedule must be at least %s", minSnapshotSchedule))
+	}
+	if p.KeepLast < 0 || p.KeepDaily < 0 || p.KeepWeekly < 0 {
+		errs = append(errs, fmt.Errorf("keep_last, keep_daily and keep_weekly must not be negative"))
+	}
+	if p.KeepLast+p.KeepDaily+p.KeepWeekly <= 0 {
+		errs = append(errs, fmt.Errorf("one of keep_last, keep_daily or keep_weekly is required"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// getSnapshotPolicy returns the snapshot policy with the given name.
+func getSnapshotPolicy(name string) (*SnapshotPolicy, error) {
+	for i := range snapshotPolicies {
+		if snapshotPolicies[i].Name == name {
+			return &snapshotPolicies[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown snapshot policy %q, see get_all_snapshot_policies for the available policies", name)
+}
+
+// SnapshotPolicyListArgs represents the arguments for listing snapshot policies.
+type SnapshotPolicyListArgs struct {
+	OutputArgs
+}
+
+// SnapshotPolicyRunArgs represents the arguments required to run a snapshot policy.
+// The policy determines the project the tool works on.
+type SnapshotPolicyRunArgs struct {
+	Policy string `json:"policy" jsonschema:"required,description=The name of the snapshot policy to run (see get_all_snapshot_policies)"`
+	WriteArgs
+	OutputArgs
+}
+
+func (a SnapshotPolicyRunArgs) projectName() string {
+	if policy, err := getSnapshotPolicy(a.Policy); err == nil {
+		return policy.Project
+	}
+	return EmptyString
+}
+
+type SnapshotPolicyResponse struct {
+	Name          string `json:"name" jsonschema:"required,description=The name of the policy"`
+	Project       string `json:"project" jsonschema:"required,description=The project the policy applies to"`
+	LabelSelector string `json:"label_selector" jsonschema:"required,description=Label selector of the servers the policy snapshots"`
+	Schedule      string `json:"schedule,omitempty" jsonschema:"description=Interval between two snapshots of a server by the scheduler; empty if the policy only runs on request"`
+	KeepLast      int    `json:"keep_last" jsonschema:"required,description=Number of newest snapshots to keep"`
+	KeepDaily     int    `json:"keep_daily" jsonschema:"required,description=Number of days to keep the newest snapshot of"`
+	KeepWeekly    int    `json:"keep_weekly" jsonschema:"required,description=Number of weeks to keep the newest snapshot of"`
+}
+
+func toSnapshotPolicyResponse(p *SnapshotPolicy) *SnapshotPolicyResponse {
+	var schedule string
+	if p.Schedule != 0 {
+		schedule = p.Schedule.String()
+	}
+	return &SnapshotPolicyResponse{
+		Name:          p.Name,
+		Project:       cmp.Or(p.Project, defaultProject),
+		LabelSelector: p.LabelSelector,
+		Schedule:      schedule,
+		KeepLast:      p.KeepLast,
+		KeepDaily:     p.KeepDaily,
+		KeepWeekly:    p.KeepWeekly,
+	}
+}
+
+type SnapshotRef struct {
+	ID          int64     `json:"id" jsonschema:"required,description=Unique identifier of the snapshot; 0 if it is not created yet"`
+	Description string    `json:"description" jsonschema:"description=The description of the snapshot"`
+	Created     time.Time `json:"created" jsonschema:"required,description=Timestamp of when the snapshot was created"`
+}
+
+type SnapshotPolicyServerResponse struct {
+	Server  *ResourceRef    `json:"server" jsonschema:"required,description=The snapshotted server"`
+	Created *ImageResponse  `json:"created" jsonschema:"description=The created snapshot; null if it could not be created"`
+	Action  *ActionResponse `json:"action" jsonschema:"description=The action creating the snapshot"`
+	Kept    []*SnapshotRef  `json:"kept" jsonschema:"description=Snapshots of the server kept by the policy"`
+	Pruned  []*SnapshotRef  `json:"pruned" jsonschema:"description=Snapshots of the server deleted by the policy"`
+	Error   string          `json:"error,omitempty" jsonschema:"description=Why the server could not be snapshotted or pruned completely"`
+}
+
+type SnapshotPolicyRunResponse struct {
+	Policy  string                          `json:"policy" jsonschema:"required,description=The name of the policy"`
+	Servers []*SnapshotPolicyServerResponse `json:"servers" jsonschema:"required,description=The servers matching the policy"`
+}
+
+func toSnapshotRef(i *hcloud.Image) *SnapshotRef {
+	return &SnapshotRef{
+		ID:          i.ID,
+		Description: i.Description,
+		Created:     i.Created,
+	}
+}
+
+// retainSnapshots splits the snapshots of a Server, sorted newest first, into the snapshots the policy keeps and
+// the ones it prunes. Protected snapshots and snapshots still being created are always kept.
+func retainSnapshots(policy *SnapshotPolicy, snapshots []*hcloud.Image) (kept, pruned []*hcloud.Image) {
+	keep := make([]bool, len(snapshots))
+	for i := range snapshots {
+		keep[i] = i < policy.KeepLast || snapshots[i].Protection.Delete || snapshots[i].Status == hcloud.ImageStatusCreating
+	}
+	keepPeriods(snapshots, keep, policy.KeepDaily, func(t time.Time) string {
+		return t.UTC().Format(time.DateOnly)
+	})
+	keepPeriods(snapshots, keep, policy.KeepWeekly, func(t time.Time) string {
+		year, week := t.UTC().ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	})
+
+	for i, snapshot := range snapshots {
+		if keep[i] {
+			kept = append(kept, snapshot)
+		} else {
+			pruned = append(pruned, snapshot)
+		}
+	}
+	return kept, pruned
+}
+
+// keepPeriods keeps the newest snapshot of each of the last count periods with snapshots.
+func keepPeriods(snapshots []*hcloud.Image, keep []bool, count int, period func(time.Time) string) {
+	last := EmptyString
+	for i := 0; i < len(snapshots) && count > 0; i++ {
+		if p := period(snapshots[i].Created); p != last {
+			keep[i] = true
+			last = p
+			count--
+		}
+	}
+}
+
+// runSnapshotPolicy snapshots the Servers matching the policy and prunes their snapshots exceeding the policy.
+// Servers with a snapshot of the policy younger than due are skipped, 0 snapshots every Server. With due,
+// Servers whose last snapshot attempt failed are also skipped until the backoff of the failure has passed.
+// Old snapshots are only pruned once the new snapshot is created. Problems with single servers are reported
+// in their results, so one failing server does not stop the others.
+func runSnapshotPolicy(ctx context.Context, policy *SnapshotPolicy, dryRun bool, due time.Duration) (*SnapshotPolicyRunResponse, []string, error) {
+	if !dryRun {
+		snapshotPolicyMu.Lock()
+		defer snapshotPolicyMu.Unlock()
+	}
+
+	servers, err := hcloudClient(ctx).Server.AllWithOpts(ctx, hcloud.ServerListOpts{
+		ListOpts: hcloud.ListOpts{LabelSelector: scopeLabelSelector(policy.LabelSelector)},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	images, err := hcloudClient(ctx).Image.AllWithOpts(ctx, hcloud.ImageListOpts{
+		ListOpts: hcloud.ListOpts{LabelSelector: scopeLabelSelector(SnapshotPolicyLabel + "=" + policy.Name)},
+		Type:     []hcloud.ImageType{hcloud.ImageTypeSnapshot},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	snapshots := make(map[string][]*hcloud.Image)
+	for _, image := range images {
+		snapshots[image.Labels[SnapshotServerLabel]] = append(snapshots[image.Labels[SnapshotServerLabel]], image)
+	}
+
+	slices.SortFunc(servers, func(a, b *hcloud.Server) int { return cmp.Compare(a.ID, b.ID) })
+	result := &SnapshotPolicyRunResponse{Policy: policy.Name, Servers: []*SnapshotPolicyServerResponse{}}
+	var warnings []string
+	now := time.Now()
+	for _, server := range servers {
+		serverSnapshots := snapshots[strconv.FormatInt(server.ID, 10)]
+		slices.SortFunc(serverSnapshots, func(a, b *hcloud.Image) int { return b.Created.Compare(a.Created) })
+		if due > 0 && len(serverSnapshots) > 0 && now.Sub(serverSnapshots[0].Created) < due {
+			continue
+		}
+		key := snapshotFailureKey{policy: policy.Name, server: server.ID}
+		if due > 0 {
+			if failure := snapshotFailures[key]; failure != nil && now.Sub(failure.last) < failure.backoff(due) {
+				continue
+			}
+		}
+
+		serverResult := &SnapshotPolicyServerResponse{Server: toServerRef(server), Kept: []*SnapshotRef{}, Pruned: []*SnapshotRef{}}
+		result.Servers = append(result.Servers, serverResult)
+		if server.Status == hcloud.ServerStatusRunning {
+			warnings = append(warnings, fmt.Sprintf("server %d is running, its snapshot is only crash-consistent", server.ID))
+		}
+
+		created, err := createPolicySnapshot(ctx, policy, server, dryRun, serverResult)
+		if err != nil {
+			serverResult.Error = err.Error()
+			if !dryRun {
+				failure := snapshotFailures[key]
+				if failure == nil {
+					failure = &snapshotFailure{}
+					snapshotFailures[key] = failure
+				}
+				failure.last = now
+				failure.attempts++
+			}
+			continue
+		}
+		if !dryRun {
+			delete(snapshotFailures, key)
+		}
+		kept, pruned := retainSnapshots(policy, append([]*hcloud.Image{created}, serverSnapshots...))
+		for _, snapshot := range kept {
+			serverResult.Kept = append(serverResult.Kept, toSnapshotRef(snapshot))
+		}
+		for _, snapshot := range pruned {
+			if err := checkScope("image", snapshot.ID, snapshot.Labels); err != nil {
+				serverResult.Error = fmt.Sprintf("failed to prune snapshot %d: %v", snapshot.ID, err)
+				continue
+			}
+			if !dryRun {
+				if _, err := hcloudClient(ctx).Image.Delete(ctx, snapshot); err != nil {
+					serverResult.Error = fmt.Sprintf("failed to prune snapshot %d: %v", snapshot.ID, err)
+					continue
+				}
+			}
+			serverResult.Pruned = append(serverResult.Pruned, toSnapshotRef(snapshot))
+		}
+	}
+
+	return result, warnings, nil
+}
+
+// createPolicySnapshot creates a snapshot of the Server labeled with the policy and waits until it is created.
+// In dry-run mode, it returns the snapshot it would create.
+func createPolicySnapshot(ctx context.Context, policy *SnapshotPolicy, server *hcloud.Server, dryRun bool, result *SnapshotPolicyServerResponse) (*hcloud.Image, error) {
+	if err := checkScope("server", server.ID, server.Labels); err != nil {
+		return nil, err
+	}
+	labels, err := scopeLabels(map[string]string{
+		SnapshotPolicyLabel: policy.Name,
+		SnapshotServerLabel: strconv.FormatInt(server.ID, 10),
+	})
+	if err != nil {
+		return nil, err
+	}
+	opts := &hcloud.ServerCreateImageOpts{
+		Type:        hcloud.ImageTypeSnapshot,
+		Description: hcloud.Ptr(fmt.Sprintf("%s snapshot of %s", policy.Name, server.Name)),
+		Labels:      labels,
+	}
+
+	if dryRun {
+		image := &hcloud.Image{
+			Description:  *opts.Description,
+			Type:         opts.Type,
+			Status:       hcloud.ImageStatusCreating,
+			Architecture: server.ServerType.Architecture,
+			DiskSize:     float32(server.PrimaryDiskSize),
+			CreatedFrom:  server,
+			Labels:       labels,
+			Created:      time.Now(),
+		}
+		result.Created = toImageResponse(image)
+		result.Action = plannedAction("create_image", ActionResource{ID: server.ID, Type: string(hcloud.ActionResourceTypeServer)})
+		return image, nil
+	}
+
+	created, _, err := hcloudClient(ctx).Server.CreateImage(ctx, server, opts)
+	if err != nil {
+		return nil, err
+	}
+	recordActions(ctx, created.Action)
+	result.Action = toActionResponse(created.Action)
+	action, err := waitForAction(ctx, created.Action)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot %d was not created, no snapshots were pruned: %w", created.Image.ID, err)
+	}
+	result.Action = toActionResponse(action)
+	image, _, err := hcloudClient(ctx).Image.GetByID(ctx, created.Image.ID)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil {
+		return nil, fmt.Errorf("snapshot %d not found, no snapshots were pruned", created.Image.ID)
+	}
+	result.Created = toImageResponse(image)
+	return image, nil
+}
+
+// runSnapshotScheduler runs the snapshot policies with a schedule whenever a Server matching them has no
+// snapshot of the policy younger than the schedule, until the context is done. Failed snapshots are retried
+// with a backoff. Policies are only scheduled if run_snapshot_policy is registered and allowed on their project.
+// Every run is written to the audit log.
+// In dry-run mode, nothing is scheduled: without created snapshots, every policy would be due on every tick.
+func runSnapshotScheduler(ctx context.Context, restriction Restriction, filter ToolFilter) {
+	if dryRunMode {
+		if slices.ContainsFunc(snapshotPolicies, func(p SnapshotPolicy) bool { return p.Schedule != 0 }) {
+			log.Printf("Snapshot policies are not scheduled in dry-run mode")
+		}
+		return
+	}
+
+	type scheduled struct {
+		policy  *SnapshotPolicy
+		project *Project
+	}
+	var policies []scheduled
+	for i := range snapshotPolicies {
+		policy := &snapshotPolicies[i]
+		if policy.Schedule == 0 {
+			continue
+		}
+		project, err := getProject(policy.Project)
+		if err != nil {
+			log.Printf("Snapshot policy %s is not scheduled: %v", policy.Name, err)
+			continue
+		}
+		if !filter.Allows("run_snapshot_policy") || !isAllowed(RestrictionReadWrite, narrowRestriction(restriction, project.Restriction)) {
+			log.Printf("Snapshot policy %s is not scheduled, run_snapshot_policy is not allowed on project %s", policy.Name, project.Name)
+			continue
+		}
+		policies = append(policies, scheduled{policy: policy, project: project})
+	}
+	if len(policies) == 0 {
+		return
+	}
+	log.Printf("Scheduled %d snapshot policies", len(policies))
+
+	ticker := time.NewTicker(snapshotSchedulerInterval)
+	defer ticker.Stop()
+	for {
+		for _, s := range policies {
+			if auditLog != nil {
+				if err := auditLog.Check(); err != nil {
+					log.Printf("Snapshot policy %s is skipped, the audit log cannot be written: %v", s.policy.Name, err)
+					continue
+				}
+			}
+			record := &AuditRecord{
+				Time:        time.Now().UTC(),
+				Tool:        "run_snapshot_policy",
+				Arguments:   map[string]any{"policy": s.policy.Name},
+				Principal:   "scheduler",
+				Project:     s.project.Name,
+				Restriction: narrowRestriction(restriction, s.project.Restriction),
+				Outcome:     AuditOutcomeSuccess,
+			}
+			runCtx := context.WithValue(context.WithValue(ctx, projectKey{}, s.project), auditRecordKey{}, record)
+			result, _, err := runSnapshotPolicy(runCtx, s.policy, false, s.policy.Schedule)
+			if err == nil && len(result.Servers) == 0 {
+				continue // Nothing was due
+			}
+			if err != nil {
+				record.Outcome = AuditOutcomeError
+				record.Error = err.Error()
+				log.Printf("Snapshot policy %s failed: %v", s.policy.Name, err)
+			} else {
+				var errs []error
+				for _, server := range result.Servers {
+					if server.Error != EmptyString {
+						errs = append(errs, fmt.Errorf("server %d: %s", server.Server.ID, server.Error))
+					}
+				}
+				if err := errors.Join(errs...); err != nil {
+					record.Outcome = AuditOutcomeError
+					record.Error = err.Error()
+				}
+				log.Printf("Snapshot policy %s snapshotted %d servers", s.policy.Name, len(result.Servers))
+			}
+			record.DurationMS = time.Since(record.Time).Milliseconds()
+			if auditLog != nil {
+				if err := auditLog.Write(record); err != nil {
+					log.Printf("Failed to write audit log: %v", err)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SnapshotPolicyTools
+var snapshotPolicyTools = []Tool{
+	{
+		Name:        "get_all_snapshot_policies",
+		Description: "Returns the snapshot retention policies configured on the server. Policies snapshot the servers matching their label selector and prune old snapshots of the policy.",
+		Handler: func(ctx context.Context, _ SnapshotPolicyListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() ([]*SnapshotPolicyResponse, error) {
+				result := make([]*SnapshotPolicyResponse, 0, len(snapshotPolicies))
+				for i := range snapshotPolicies {
+					result = append(result, toSnapshotPolicyResponse(&snapshotPolicies[i]))
+				}
+				return result, nil
+			})
+		},
+		Restriction: RestrictionReadOnly,
+	},
+	{
+		Name:        "run_snapshot_policy",
+		Description: "Runs a snapshot retention policy now: snapshots every server matching the policy, waits for the snapshots and deletes the snapshots of the policy exceeding keep_last, keep_daily and keep_weekly. Use dry_run to list what would be created and pruned.",
+		Handler: func(ctx context.Context, args SnapshotPolicyRunArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				policy, err := getSnapshotPolicy(args.Policy)
+				if err != nil {
+					return nil, err
+				}
+				result, warnings, err := runSnapshotPolicy(ctx, policy, args.isDryRun(), 0)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					return newPlannedChange("run_snapshot_policy", result, warnings...), nil
+				}
+				return result, nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+}
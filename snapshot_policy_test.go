@@ -0,0 +1,267 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// testSnapshot returns an available snapshot created at the given RFC 3339 time.
+func testSnapshot(t *testing.T, id int64, created string) *hcloud.Image {
+	t.Helper()
+	createdAt, err := time.Parse(time.RFC3339, created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &hcloud.Image{ID: id, Type: hcloud.ImageTypeSnapshot, Status: hcloud.ImageStatusAvailable, Created: createdAt}
+}
+
+// imageIDs returns the IDs of the images in order.
+func imageIDs(images []*hcloud.Image) []int64 {
+	ids := make([]int64, 0, len(images))
+	for _, image := range images {
+		ids = append(ids, image.ID)
+	}
+	return ids
+}
+
+func TestRetainSnapshots(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     SnapshotPolicy
+		snapshots  []string // creation times newest first, the IDs are numbered from 1
+		protected  []int64
+		creating   []int64
+		wantKept   []int64
+		wantPruned []int64
+	}{
+		{
+			name:       "keep last",
+			policy:     SnapshotPolicy{KeepLast: 2},
+			snapshots:  []string{"2026-10-18T02:00:00Z", "2026-10-18T01:00:00Z", "2026-10-17T02:00:00Z", "2026-10-16T02:00:00Z"},
+			wantKept:   []int64{1, 2},
+			wantPruned: []int64{3, 4},
+		},
+		{
+			name:      "keep more than available",
+			policy:    SnapshotPolicy{KeepLast: 3, KeepDaily: 7, KeepWeekly: 4},
+			snapshots: []string{"2026-10-18T02:00:00Z", "2026-10-18T01:00:00Z"},
+			wantKept:  []int64{1, 2},
+		},
+		{
+			name:   "keep daily across midnight",
+			policy: SnapshotPolicy{KeepDaily: 3},
+			snapshots: []string{
+				"2026-10-18T00:00:00Z",
+				"2026-10-17T23:59:59Z",
+				"2026-10-17T00:00:00Z",
+				"2026-10-16T23:59:59Z",
+				"2026-10-16T00:00:00Z",
+				"2026-10-15T23:59:59Z",
+			},
+			wantKept:   []int64{1, 2, 4},
+			wantPruned: []int64{3, 5, 6},
+		},
+		{
+			name:   "keep daily in UTC",
+			policy: SnapshotPolicy{KeepDaily: 2},
+			snapshots: []string{
+				// The first two snapshots are on the 17th in UTC
+				"2026-10-18T01:00:00+02:00",
+				"2026-10-17T23:30:00Z",
+				"2026-10-17T01:00:00+02:00",
+			},
+			wantKept:   []int64{1, 3},
+			wantPruned: []int64{2},
+		},
+		{
+			name:       "keep daily skips days without snapshots",
+			policy:     SnapshotPolicy{KeepDaily: 2},
+			snapshots:  []string{"2026-10-18T02:00:00Z", "2026-10-10T02:00:00Z", "2026-10-01T02:00:00Z"},
+			wantKept:   []int64{1, 2},
+			wantPruned: []int64{3},
+		},
+		{
+			name:   "keep weekly across monday",
+			policy: SnapshotPolicy{KeepWeekly: 2},
+			snapshots: []string{
+				"2026-10-19T00:00:00Z", // Monday, week 43
+				"2026-10-18T23:59:59Z", // Sunday, week 42
+				"2026-10-12T00:00:00Z", // Monday, week 42
+				"2026-10-11T23:59:59Z", // Sunday, week 41
+			},
+			wantKept:   []int64{1, 2},
+			wantPruned: []int64{3, 4},
+		},
+		{
+			name:   "keep weekly across the new year",
+			policy: SnapshotPolicy{KeepWeekly: 2},
+			snapshots: []string{
+				"2026-01-01T12:00:00Z", // Thursday, week 1 of 2026
+				"2025-12-29T12:00:00Z", // Monday, week 1 of 2026
+				"2025-12-28T12:00:00Z", // Sunday, week 52 of 2025
+				"2025-12-21T12:00:00Z", // Sunday, week 51 of 2025
+			},
+			wantKept:   []int64{1, 3},
+			wantPruned: []int64{2, 4},
+		},
+		{
+			name:   "combined policies",
+			policy: SnapshotPolicy{KeepLast: 2, KeepDaily: 3, KeepWeekly: 3},
+			snapshots: []string{
+				"2026-10-18T12:00:00Z", // Last, daily and weekly
+				"2026-10-18T06:00:00Z", // Last
+				"2026-10-18T00:00:00Z",
+				"2026-10-17T12:00:00Z", // Daily
+				"2026-10-17T06:00:00Z",
+				"2026-10-15T12:00:00Z", // Daily
+				"2026-10-12T12:00:00Z",
+				"2026-10-11T12:00:00Z", // Weekly
+				"2026-10-05T12:00:00Z",
+				"2026-10-04T12:00:00Z", // Weekly
+				"2026-09-27T12:00:00Z",
+			},
+			wantKept:   []int64{1, 2, 4, 6, 8, 10},
+			wantPruned: []int64{3, 5, 7, 9, 11},
+		},
+		{
+			name:       "protected and creating snapshots",
+			policy:     SnapshotPolicy{KeepLast: 1},
+			snapshots:  []string{"2026-10-18T02:00:00Z", "2026-10-17T02:00:00Z", "2026-10-16T02:00:00Z", "2026-10-15T02:00:00Z"},
+			protected:  []int64{3},
+			creating:   []int64{2},
+			wantKept:   []int64{1, 2, 3},
+			wantPruned: []int64{4},
+		},
+		{
+			name:       "protected snapshots count for keep last",
+			policy:     SnapshotPolicy{KeepLast: 2},
+			snapshots:  []string{"2026-10-18T02:00:00Z", "2026-10-17T02:00:00Z", "2026-10-16T02:00:00Z"},
+			protected:  []int64{1},
+			wantKept:   []int64{1, 2},
+			wantPruned: []int64{3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			snapshots := make([]*hcloud.Image, 0, len(tt.snapshots))
+			for i, created := range tt.snapshots {
+				snapshot := testSnapshot(t, int64(i+1), created)
+				snapshot.Protection.Delete = slices.Contains(tt.protected, snapshot.ID)
+				if slices.Contains(tt.creating, snapshot.ID) {
+					snapshot.Status = hcloud.ImageStatusCreating
+				}
+				snapshots = append(snapshots, snapshot)
+			}
+
+			kept, pruned := retainSnapshots(&tt.policy, snapshots)
+			if got := imageIDs(kept); !slices.Equal(got, tt.wantKept) {
+				t.Errorf("retainSnapshots() kept = %v, want %v", got, tt.wantKept)
+			}
+			if got := imageIDs(pruned); !slices.Equal(got, tt.wantPruned) {
+				t.Errorf("retainSnapshots() pruned = %v, want %v", got, tt.wantPruned)
+			}
+		})
+	}
+}
+
+func TestSnapshotPolicyValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     SnapshotPolicy
+		wantErrors []string
+	}{
+		{
+			name:   "valid",
+			policy: SnapshotPolicy{Name: "nightly", LabelSelector: "backup=daily", Schedule: 24 * time.Hour, KeepLast: 3, KeepDaily: 7, KeepWeekly: 4},
+		},
+		{
+			name:   "only on request",
+			policy: SnapshotPolicy{Name: "pre-deploy_1.2", LabelSelector: "role in (web,db)", KeepWeekly: 1},
+		},
+		{
+			name:   "invalid name",
+			policy: SnapshotPolicy{Name: "nightly backups", LabelSelector: "backup=daily", KeepLast: 1},
+			wantErrors: []string{
+				`invalid name "nightly backups", must be a label value of at most 63 alphanumeric characters, '-', '_' or '.'`,
+			},
+		},
+		{
+			name:   "name too long",
+			policy: SnapshotPolicy{Name: strings.Repeat("a", 64), LabelSelector: "backup=daily", KeepLast: 1},
+			wantErrors: []string{
+				`invalid name "` + strings.Repeat("a", 64) + `", must be a label value of at most 63 alphanumeric characters, '-', '_' or '.'`,
+			},
+		},
+		{
+			name:   "missing label selector and keeps",
+			policy: SnapshotPolicy{Name: "nightly"},
+			wantErrors: []string{
+				"missing label selector, policies never apply to all servers",
+				"one of keep_last, keep_daily or keep_weekly is required",
+			},
+		},
+		{
+			name:   "invalid label selector",
+			policy: SnapshotPolicy{Name: "nightly", LabelSelector: "=daily", KeepLast: 1},
+			wantErrors: []string{
+				`invalid label selector: invalid label selector requirement "=daily"`,
+			},
+		},
+		{
+			name:   "short schedule and negative keeps",
+			policy: SnapshotPolicy{Name: "nightly", LabelSelector: "backup", Schedule: 30 * time.Minute, KeepLast: 3, KeepDaily: -1},
+			wantErrors: []string{
+				"schedule must be at least 1h0m0s",
+				"keep_last, keep_daily and keep_weekly must not be negative",
+			},
+		},
+		{
+			name:   "keeps cancel out",
+			policy: SnapshotPolicy{Name: "nightly", LabelSelector: "backup", KeepLast: 1, KeepWeekly: -1},
+			wantErrors: []string{
+				"keep_last, keep_daily and keep_weekly must not be negative",
+				"one of keep_last, keep_daily or keep_weekly is required",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate()
+			if len(tt.wantErrors) == 0 {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+			if want := strings.Join(tt.wantErrors, "\n"); err == nil || err.Error() != want {
+				t.Errorf("Validate() error = %v, want\n%s", err, want)
+			}
+		})
+	}
+}
+
+func TestSnapshotFailureBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		schedule time.Duration
+		want     time.Duration
+	}{
+		{name: "first failure", attempts: 1, schedule: 24 * time.Hour, want: time.Minute},
+		{name: "doubled per failure", attempts: 4, schedule: 24 * time.Hour, want: 8 * time.Minute},
+		{name: "at most the schedule", attempts: 8, schedule: time.Hour, want: time.Hour},
+		{name: "many failures", attempts: 1000, schedule: 24 * time.Hour, want: 24 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failure := &snapshotFailure{attempts: tt.attempts}
+			if got := failure.backoff(tt.schedule); got != tt.want {
+				t.Errorf("backoff(%v) = %v, want %v", tt.schedule, got, tt.want)
+			}
+		})
+	}
+}
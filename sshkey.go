@@ -1,7 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strings"
 	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
@@ -12,6 +20,47 @@ import (
 // It contains the SSH key ID or Name that is needed to perform the lookup.
 type SSHKeyReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The ssh key id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// SSHKeyListArgs represents the arguments for listing SSHKeys.
+// SSHKeys can be filtered by name and fingerprint on top of the label selector.
+type SSHKeyListArgs struct {
+	Name        string `json:"name,omitempty" jsonschema:"description=Only return the ssh key with this name"`
+	Fingerprint string `json:"fingerprint,omitempty" jsonschema:"description=Only return the ssh key with this fingerprint"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// SSHKeyCreateArgs represents the arguments required to create an SSH key.
+type SSHKeyCreateArgs struct {
+	Name      string            `json:"name,omitempty" jsonschema:"description=The ssh key name. Defaults to the comment of the public key (e.g. user@host)"`
+	PublicKey string            `json:"public_key" jsonschema:"required,description=The public key in authorized_keys format (e.g. ssh-ed25519 AAAA... user@host)"`
+	Labels    map[string]string `json:"labels,omitempty" jsonschema:"description=User-defined labels for the ssh key"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// SSHKeyActionArgs represents the arguments required to change an SSH key.
+// It contains the SSH key ID the change is performed on.
+type SSHKeyActionArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The ssh key id"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// SSHKeyUpdateArgs represents the arguments required to rename an SSH key or replace its labels.
+type SSHKeyUpdateArgs struct {
+	ID     int64             `json:"id" jsonschema:"required,description=The ssh key id"`
+	Name   string            `json:"name,omitempty" jsonschema:"description=New name of the ssh key"`
+	Labels map[string]string `json:"labels,omitempty" jsonschema:"description=New labels of the ssh key. Replaces all existing labels"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
 }
 
 type SSHKeyResponse struct {
@@ -19,6 +68,9 @@ type SSHKeyResponse struct {
 	Name        string            `json:"name" jsonschema:"required,description=The name of the ssh key"`
 	Fingerprint string            `json:"fingerprint" jsonschema:"required,description=The fingerprint of the ssh key"`
 	PublicKey   string            `json:"public_key" jsonschema:"required,description=The public key of the ssh key"`
+	Type        string            `json:"type,omitempty" jsonschema:"description=The key type (e.g. ssh-ed25519 or ssh-rsa)"`
+	Bits        int               `json:"bits,omitempty" jsonschema:"description=The key length in bits"`
+	Comment     string            `json:"comment,omitempty" jsonschema:"description=The comment of the public key (e.g. user@host)"`
 	Labels      map[string]string `json:"labels" jsonschema:"description=User-defined labels for the ssh key"`
 	Created     time.Time         `json:"created" jsonschema:"required,description=Timestamp of when the ssh key was created"`
 }
@@ -28,7 +80,7 @@ func tossSSHKeyResponse(s *hcloud.SSHKey) *SSHKeyResponse {
 		return nil
 	}
 
-	return &SSHKeyResponse{
+	result := &SSHKeyResponse{
 		ID:          s.ID,
 		Name:        s.Name,
 		Fingerprint: s.Fingerprint,
@@ -36,24 +88,277 @@ func tossSSHKeyResponse(s *hcloud.SSHKey) *SSHKeyResponse {
 		Labels:      s.Labels,
 		Created:     s.Created,
 	}
+	// Keys the API accepted but which cannot be parsed here are returned without details
+	if key, err := parsePublicKey(s.PublicKey); err == nil {
+		result.Type = key.Type
+		result.Bits = key.Bits
+		result.Comment = key.Comment
+	}
+	return result
+}
+
+// minRSAKeyBits is the minimum length of RSA keys, shorter keys can be factored with reasonable effort.
+const minRSAKeyBits = 2048
+
+// publicKey is a parsed public key in authorized_keys format.
+type publicKey struct {
+	Type    string
+	Bits    int
+	Comment string
+	// Blob is the key in SSH wire format
+	Blob []byte
+}
+
+// String returns the key in authorized_keys format.
+func (k *publicKey) String() string {
+	return strings.TrimSpace(k.Type + " " + base64.StdEncoding.EncodeToString(k.Blob) + " " + k.Comment)
+}
+
+// Fingerprint returns the MD5 fingerprint used by the Hetzner Cloud API (e.g. b7:2f:30:a0:...).
+func (k *publicKey) Fingerprint() string {
+	sum := md5.Sum(k.Blob)
+	parts := make([]string, len(sum))
+	for i, b := range sum {
+		parts[i] = fmt.Sprintf("%02x", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// SHA256Fingerprint returns the SHA256 fingerprint shown by ssh-keygen -l (e.g. SHA256:nThbg6k...).
+func (k *publicKey) SHA256Fingerprint() string {
+	sum := sha256.Sum256(k.Blob)
+	return "SHA256:" + base64.RawStdEncoding.EncodeToString(sum[:])
+}
+
+// readSSHString reads a length-prefixed string of the SSH wire format and returns it with the remaining data.
+func readSSHString(data []byte) ([]byte, []byte, bool) {
+	if len(data) < 4 {
+		return nil, nil, false
+	}
+	length := binary.BigEndian.Uint32(data)
+	if uint64(len(data)-4) < uint64(length) {
+		return nil, nil, false
+	}
+	return data[4 : 4+length], data[4+length:], true
+}
+
+// parsePublicKey parses a single public key in authorized_keys format, e.g. the content of ~/.ssh/id_ed25519.pub.
+func parsePublicKey(value string) (*publicKey, error) {
+	value = strings.TrimSpace(value)
+	if strings.Contains(value, "PRIVATE KEY") {
+		// Never echo the value, it is a secret
+		return nil, fmt.Errorf("the public key is a private key, use the content of the .pub file instead")
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		return nil, fmt.Errorf("the public key must be a single key on one line")
+	}
+	fields := strings.Fields(value)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("the public key must be in authorized_keys format (e.g. ssh-ed25519 AAAA... user@host)")
+	}
+	blob, err := base64.StdEncoding.DecodeString(fields[1])
+	if err != nil {
+		return nil, fmt.Errorf("the public key must be in authorized_keys format (e.g. ssh-ed25519 AAAA... user@host), the key data is not valid base64")
+	}
+
+	keyType, rest, ok := readSSHString(blob)
+	if !ok {
+		return nil, fmt.Errorf("the key data of the public key is truncated")
+	}
+	if string(keyType) != fields[0] {
+		return nil, fmt.Errorf("the public key is of type %s but its key data is of type %s", fields[0], keyType)
+	}
+
+	key := &publicKey{
+		Type:    fields[0],
+		Comment: strings.Join(fields[2:], " "),
+		Blob:    blob,
+	}
+	switch key.Type {
+	case "ssh-rsa", "ssh-dss":
+		// RSA keys start with the exponent and DSA keys with the prime, the key length is the length of the
+		// modulus and the prime respectively
+		first, rest, ok := readSSHString(rest)
+		if ok && key.Type == "ssh-rsa" {
+			first, _, ok = readSSHString(rest)
+		}
+		if !ok {
+			return nil, fmt.Errorf("the key data of the public key is truncated")
+		}
+		key.Bits = new(big.Int).SetBytes(bytes.TrimLeft(first, "\x00")).BitLen()
+	case "ssh-ed25519", "sk-ssh-ed25519@openssh.com", "ecdsa-sha2-nistp256", "sk-ecdsa-sha2-nistp256@openssh.com":
+		key.Bits = 256
+	case "ecdsa-sha2-nistp384":
+		key.Bits = 384
+	case "ecdsa-sha2-nistp521":
+		key.Bits = 521
+	default:
+		return nil, fmt.Errorf("unsupported public key type %s, use ssh-ed25519, ecdsa-sha2-nistp256/384/521 or ssh-rsa", key.Type)
+	}
+	return key, nil
+}
+
+// checkPublicKeyStrength rejects key types and lengths which are considered broken.
+func checkPublicKeyStrength(key *publicKey) error {
+	switch {
+	case key.Type == "ssh-dss":
+		return fmt.Errorf("DSA keys are insecure and no longer supported by OpenSSH, use an ssh-ed25519 key instead")
+	case key.Type == "ssh-rsa" && key.Bits < minRSAKeyBits:
+		return fmt.Errorf("the RSA key has %d bits, at least %d bits are required. Use an ssh-ed25519 key instead", key.Bits, minRSAKeyBits)
+	}
+	return nil
+}
+
+// toSSHKeyCreateOpts parses and checks the public key, then converts the arguments to the options for creating an SSH key.
+// It also returns the SSH key the options are expected to result in.
+func toSSHKeyCreateOpts(ctx context.Context, args SSHKeyCreateArgs) (hcloud.SSHKeyCreateOpts, *hcloud.SSHKey, error) {
+	key, err := parsePublicKey(args.PublicKey)
+	if err != nil {
+		return hcloud.SSHKeyCreateOpts{}, nil, err
+	}
+	if err := checkPublicKeyStrength(key); err != nil {
+		return hcloud.SSHKeyCreateOpts{}, nil, err
+	}
+
+	name := args.Name
+	if name == EmptyString {
+		name = key.Comment
+	}
+	if name == EmptyString {
+		return hcloud.SSHKeyCreateOpts{}, nil, fmt.Errorf("missing ssh key name, the public key has no comment to use instead")
+	}
+	labels, err := scopeLabels(args.Labels)
+	if err != nil {
+		return hcloud.SSHKeyCreateOpts{}, nil, err
+	}
+
+	// The API rejects duplicates as well, but only with a generic uniqueness error
+	existing, _, err := hcloudClient(ctx).SSHKey.GetByFingerprint(ctx, key.Fingerprint())
+	if err != nil {
+		return hcloud.SSHKeyCreateOpts{}, nil, err
+	}
+	if existing != nil && !visibleInScope(existing.Labels) {
+		// Keys hidden by the label scope must not be revealed
+		return hcloud.SSHKeyCreateOpts{}, nil, fmt.Errorf("the public key (%s) already exists in the project", key.SHA256Fingerprint())
+	}
+	if existing != nil {
+		return hcloud.SSHKeyCreateOpts{}, nil, fmt.Errorf("the public key (%s) already exists as ssh key %s (%d)", key.SHA256Fingerprint(), existing.Name, existing.ID)
+	}
+	existing, _, err = hcloudClient(ctx).SSHKey.GetByName(ctx, name)
+	if err != nil {
+		return hcloud.SSHKeyCreateOpts{}, nil, err
+	}
+	if existing != nil {
+		return hcloud.SSHKeyCreateOpts{}, nil, fmt.Errorf("ssh key %s already exists", name)
+	}
+
+	opts := hcloud.SSHKeyCreateOpts{
+		Name:      name,
+		PublicKey: key.String(),
+		Labels:    labels,
+	}
+	return opts, &hcloud.SSHKey{
+		Name:        opts.Name,
+		Fingerprint: key.Fingerprint(),
+		PublicKey:   opts.PublicKey,
+		Labels:      opts.Labels,
+		Created:     time.Now(),
+	}, nil
+}
+
+// getSSHKey retrieves an SSH key by its ID and fails if the SSH key does not exist.
+func getSSHKey(ctx context.Context, id int64) (*hcloud.SSHKey, error) {
+	sshKey, _, err := hcloudClient(ctx).SSHKey.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sshKey == nil {
+		return nil, fmt.Errorf("ssh key %d not found", id)
+	}
+	return sshKey, nil
+}
+
+// getSSHKeys retrieves SSH keys by their IDs or names and fails if one of them does not exist.
+func getSSHKeys(ctx context.Context, idOrNames []string) ([]*hcloud.SSHKey, error) {
+	sshKeys := make([]*hcloud.SSHKey, 0, len(idOrNames))
+	for _, idOrName := range idOrNames {
+		sshKey, _, err := hcloudClient(ctx).SSHKey.Get(ctx, idOrName)
+		if err != nil {
+			return nil, err
+		}
+		if sshKey == nil {
+			return nil, fmt.Errorf("ssh key %s not found", idOrName)
+		}
+		sshKeys = append(sshKeys, sshKey)
+	}
+	return sshKeys, nil
+}
+
+// runSSHKeyChange looks up an SSH key and applies the given change to it.
+// check validates the change in advance and returns the SSH key it is expected to result in,
+// or no SSH key if the change deletes it. In dry-run mode, the expected SSH key is returned
+// as planned change. SSH keys are changed without an Action, so the final state is returned right away.
+func runSSHKeyChange(ctx context.Context, id int64, write WriteArgs, command string,
+	check func(context.Context, *hcloud.SSHKey) (*SSHKeyResponse, []string, error),
+	change func(context.Context, *hcloud.SSHKey) error,
+) (*mcpgolang.ToolResponse, error) {
+	return handleResponse(ctx, func() (any, error) {
+		sshKey, err := getSSHKey(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkScope("ssh key", sshKey.ID, sshKey.Labels); err != nil {
+			return nil, err
+		}
+		expected, warnings, err := check(ctx, sshKey)
+		if err != nil {
+			return nil, err
+		}
+
+		if write.isDryRun() {
+			return newPlannedChange(command, expected, warnings...), nil
+		}
+
+		if err := change(ctx, sshKey); err != nil {
+			return nil, err
+		}
+		if expected == nil {
+			// The SSH key is deleted
+			return expected, nil
+		}
+		sshKey, _, err = hcloudClient(ctx).SSHKey.GetByID(ctx, sshKey.ID)
+		if err != nil {
+			return nil, err
+		}
+		return tossSSHKeyResponse(sshKey), nil
+	})
 }
 
 // SSHKeyTools
 var sshkeyTools = []Tool{
 	{
 		Name:        "get_all_ssh_keys",
-		Description: "Returns all ssh-key objects. SSH keys are public keys you provide to the cloud system. They can be injected into Servers at creation time.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*SSHKeyResponse, error) {
-				result, err := client.SSHKey.All(context.Background())
+		Description: "Returns a page of ssh-key objects, optionally filtered by name, fingerprint and label selector. SSH keys are public keys you provide to the cloud system. They can be injected into Servers at creation time.",
+		Handler: func(ctx context.Context, args SSHKeyListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*SSHKeyResponse], error) {
+				listOpts, err := args.listOpts()
 				if err != nil {
 					return nil, err
 				}
-				var filtered []*SSHKeyResponse
-				for _, s := range result {
-					filtered = append(filtered, tossSSHKeyResponse(s))
+				opts := hcloud.SSHKeyListOpts{
+					ListOpts:    listOpts,
+					Name:        args.Name,
+					Fingerprint: args.Fingerprint,
+				}
+				result, resp, err := hcloudClient(ctx).SSHKey.List(ctx, opts)
+				if err != nil {
+					return nil, err
 				}
-				return filtered, nil
+				items := make([]*SSHKeyResponse, 0, len(result))
+				for _, item := range result {
+					items = append(items, tossSSHKeyResponse(item))
+				}
+				return newListResponse(items, resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -61,12 +366,93 @@ var sshkeyTools = []Tool{
 	{
 		Name:        "get_a_ssh_key_by_id_or_name",
 		Description: "Retrieves a SSH key by its ID or Name, Get retrieves a SSH key by its ID if the input can be parsed as an integer, otherwise it retrieves a SSH key by its name. If the SSH key does not exist, nil is returned.",
-		Handler: func(args SSHKeyReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*SSHKeyResponse, error) {
-				result, _, err := client.SSHKey.Get(context.Background(), args.IDOrName)
-				return tossSSHKeyResponse(result), err
+		Handler: func(ctx context.Context, args SSHKeyReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*SSHKeyResponse, error) {
+				result, _, err := hcloudClient(ctx).SSHKey.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return tossSSHKeyResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
 	},
+	{
+		Name:        "create_a_ssh_key",
+		Description: "Uploads a public SSH key to inject into new Servers. The key is parsed first: DSA and RSA keys shorter than 2048 bits are rejected, and keys already in the project are reported with the name of the existing ssh key.",
+		Handler: func(ctx context.Context, args SSHKeyCreateArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				opts, expected, err := toSSHKeyCreateOpts(ctx, args)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					return newPlannedChange("create_ssh_key", tossSSHKeyResponse(expected)), nil
+				}
+				result, _, err := hcloudClient(ctx).SSHKey.Create(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return tossSSHKeyResponse(result), nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "update_a_ssh_key",
+		Description: "Renames an SSH key or replaces its labels.",
+		Handler: func(ctx context.Context, args SSHKeyUpdateArgs) (*mcpgolang.ToolResponse, error) {
+			var opts hcloud.SSHKeyUpdateOpts
+			return runSSHKeyChange(ctx, args.ID, args.WriteArgs, "update_ssh_key",
+				func(ctx context.Context, sshKey *hcloud.SSHKey) (*SSHKeyResponse, []string, error) {
+					if args.Name == EmptyString && args.Labels == nil {
+						return nil, nil, fmt.Errorf("either name or labels is required")
+					}
+					expected := tossSSHKeyResponse(sshKey)
+					if args.Name != EmptyString && args.Name != sshKey.Name {
+						existing, _, err := hcloudClient(ctx).SSHKey.GetByName(ctx, args.Name)
+						if err != nil {
+							return nil, nil, err
+						}
+						if existing != nil {
+							return nil, nil, fmt.Errorf("ssh key %s already exists", args.Name)
+						}
+						opts.Name = args.Name
+						expected.Name = args.Name
+					}
+					if args.Labels != nil {
+						labels, err := scopeLabels(args.Labels)
+						if err != nil {
+							return nil, nil, err
+						}
+						opts.Labels = labels
+						expected.Labels = labels
+					}
+					return expected, nil, nil
+				},
+				func(ctx context.Context, sshKey *hcloud.SSHKey) error {
+					_, _, err := hcloudClient(ctx).SSHKey.Update(ctx, sshKey, opts)
+					return err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_a_ssh_key",
+		Description: "Deletes an SSH key. Servers created with the key keep it in their authorized_keys, so access to them has to be revoked on the servers themselves.",
+		Handler: func(ctx context.Context, args SSHKeyActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runSSHKeyChange(ctx, args.ID, args.WriteArgs, "delete_ssh_key",
+				func(ctx context.Context, sshKey *hcloud.SSHKey) (*SSHKeyResponse, []string, error) {
+					return nil, []string{fmt.Sprintf("servers created with ssh key %s still accept it until it is removed from their authorized_keys", sshKey.Name)}, nil
+				},
+				func(ctx context.Context, sshKey *hcloud.SSHKey) error {
+					_, err := hcloudClient(ctx).SSHKey.Delete(ctx, sshKey)
+					return err
+				})
+		},
+		Restriction: RestrictionReadWrite,
+	},
 }
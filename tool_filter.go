@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ToolFilter narrows down the tools allowed by the restriction.
+// Patterns are exact tool names or glob patterns like get_* or *_volume*.
+type ToolFilter struct {
+	// Allow lists the tools to register. An empty list allows all tools.
+	Allow []string
+	// Deny lists the tools never to register, even if they are allowed.
+	Deny []string
+}
+
+// splitToolPatterns splits a comma separated list of tool patterns.
+func splitToolPatterns(value string) []string {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern != EmptyString {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// Validate checks that all patterns are valid glob patterns.
+func (f ToolFilter) Validate() error {
+	for _, pattern := range append(append([]string{}, f.Allow...), f.Deny...) {
+		if _, err := path.Match(pattern, EmptyString); err != nil {
+			return fmt.Errorf("invalid tool pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Allows reports whether the tool with the given name passes the filter.
+func (f ToolFilter) Allows(name string) bool {
+	if matchesAny(f.Deny, name) {
+		return false
+	}
+	return len(f.Allow) == 0 || matchesAny(f.Allow, name)
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
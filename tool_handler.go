@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+	"time"
+)
+
+var (
+	contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+	errorType   = reflect.TypeOf((*error)(nil)).Elem()
+)
+
+// wrapToolHandler wraps the handler of a tool to select the project, to check the restrictions
+// of the client calling it and of the project, and to write every invocation to the audit log.
+// Tools are refused while the audit log cannot be written, so that no invocation goes unrecorded.
+// The wrapped handler always takes a context, which is passed on to the original handler.
+func wrapToolHandler(tool Tool, restriction Restriction) any {
+	handler := reflect.ValueOf(tool.Handler)
+	handlerType := handler.Type()
+	argsType := handlerType.In(handlerType.NumIn() - 1)
+	wrapperType := reflect.FuncOf(
+		[]reflect.Type{contextType, argsType},
+		[]reflect.Type{handlerType.Out(0), handlerType.Out(1)},
+		false,
+	)
+
+	return reflect.MakeFunc(wrapperType, func(in []reflect.Value) []reflect.Value {
+		ctx := in[0].Interface().(context.Context)
+		record := &AuditRecord{
+			Time:        time.Now().UTC(),
+			Tool:        tool.Name,
+			Arguments:   redactArguments(in[1].Interface()),
+			Restriction: restriction,
+		}
+		record.DryRun = dryRunMode || record.Arguments["dry_run"] == true
+		ctx = context.WithValue(ctx, auditRecordKey{}, record)
+
+		var out []reflect.Value
+		ctx, err := authorizeTool(ctx, tool, in[1].Interface(), record)
+		if err == nil && auditLog != nil {
+			if checkErr := auditLog.Check(); checkErr != nil {
+				err = fmt.Errorf("tool %s is refused, the audit log cannot be written: %w", tool.Name, checkErr)
+			}
+		}
+		if err != nil {
+			out = errorResult(handlerType, err)
+		} else {
+			args := []reflect.Value{in[1]}
+			if handlerType.NumIn() == 2 {
+				args = []reflect.Value{reflect.ValueOf(ctx), in[1]}
+			}
+			out = handler.Call(args)
+		}
+
+		record.Outcome = AuditOutcomeSuccess
+		if err, _ := out[1].Interface().(error); err != nil {
+			record.Outcome = AuditOutcomeError
+			record.Error = err.Error()
+		}
+		record.DurationMS = time.Since(record.Time).Milliseconds()
+
+		if auditLog != nil {
+			if err := auditLog.Write(record); err != nil {
+				log.Printf("Failed to write audit log: %v", err)
+				if record.Outcome == AuditOutcomeSuccess {
+					out = errorResult(handlerType, fmt.Errorf("tool %s ran, but its invocation could not be written to the audit log: %w", tool.Name, err))
+				}
+			}
+		}
+
+		return out
+	}).Interface()
+}
+
+// authorizeTool selects the project and output options given in the arguments and checks that the
+// tool may be used with the restrictions of the client calling it and of the project.
+// The restriction in effect is the most restrictive of the server, the client and the project.
+func authorizeTool(ctx context.Context, tool Tool, args any, record *AuditRecord) (context.Context, error) {
+	if principal := principalFromContext(ctx); principal != nil {
+		record.Principal = principal.Name
+		record.Restriction = narrowRestriction(record.Restriction, principal.Restriction)
+		if !isAllowed(tool.Restriction, record.Restriction) {
+			return ctx, fmt.Errorf("tool %s is not allowed for %s with restriction %s", tool.Name, principal.Name, principal.Restriction)
+		}
+	}
+
+	if a, ok := args.(interface{ projectName() string }); ok {
+		project, err := getProject(a.projectName())
+		if err != nil {
+			return ctx, err
+		}
+		record.Project = project.Name
+		record.Restriction = narrowRestriction(record.Restriction, project.Restriction)
+		if !isAllowed(tool.Restriction, record.Restriction) {
+			return ctx, fmt.Errorf("tool %s is not allowed on project %s with restriction %s", tool.Name, project.Name, project.Restriction)
+		}
+		ctx = context.WithValue(ctx, projectKey{}, project)
+	}
+
+	if a, ok := args.(interface{ outputOptions() OutputArgs }); ok {
+		output := a.outputOptions()
+		if err := output.Validate(); err != nil {
+			return ctx, err
+		}
+		ctx = context.WithValue(ctx, outputKey{}, output)
+	}
+
+	return ctx, nil
+}
+
+// narrowRestriction returns the more restrictive of both restrictions.
+func narrowRestriction(a, b Restriction) Restriction {
+	if a == RestrictionReadWrite {
+		return b
+	}
+	return a
+}
+
+// errorResult returns the results of a handler of the given type failing with the error.
+func errorResult(handlerType reflect.Type, err error) []reflect.Value {
+	errValue := reflect.New(errorType).Elem()
+	errValue.Set(reflect.ValueOf(err))
+	return []reflect.Value{reflect.Zero(handlerType.Out(0)), errValue}
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/metoro-io/mcp-golang/transport"
+	"github.com/metoro-io/mcp-golang/transport/stdio"
+)
+
+// Supported transports
+const (
+	TransportStdio = "stdio"
+	TransportHTTP  = "http"
+	TransportSSE   = "sse"
+)
+
+// Endpoints of the network transports
+const (
+	httpEndpoint       = "/mcp"
+	sseEndpoint        = "/sse"
+	sseMessageEndpoint = "/message"
+)
+
+// maxMessageSize is the maximum size of a JSON-RPC message accepted over the network.
+const maxMessageSize = 4 * 1024 * 1024
+
+// shutdownTimeout is the maximum time to wait for running requests when shutting down.
+const shutdownTimeout = 30 * time.Second
+
+// newTransport returns the transport of the given kind.
+// Network transports listen on the given address and authenticate clients with the authenticator.
+func newTransport(kind string, listen string, auth *Authenticator) (transport.Transport, error) {
+	switch kind {
+	case TransportStdio:
+		return stdio.NewStdioServerTransport(), nil
+	case TransportHTTP, TransportSSE:
+		return newHTTPTransport(kind, listen, auth), nil
+	}
+	return nil, fmt.Errorf("invalid transport %q, must be one of stdio, http or sse", kind)
+}
+
+// pendingRequest is a request received from a client which waits for its response.
+type pendingRequest struct {
+	// id is the request ID used by the client
+	id transport.RequestId
+	// deliver sends the response to the client
+	deliver func(*transport.BaseJsonRpcMessage)
+}
+
+// sseSession is an event stream opened by a client of the sse transport.
+type sseSession struct {
+	ctx       context.Context
+	events    chan *transport.BaseJsonRpcMessage
+	principal *Principal
+}
+
+// httpTransport serves MCP over HTTP to any number of clients at the same time.
+//
+// With the http transport, clients POST every JSON-RPC message to /mcp and receive
+// the response in the HTTP response. With the sse transport, clients open an event
+// stream at /sse, POST messages to the endpoint announced on the stream, and receive
+// the responses as events on the stream.
+//
+// Request IDs of all clients are replaced by unique IDs before passing them to the
+// server, so that responses can be routed back to the client that sent the request.
+type httpTransport struct {
+	kind   string
+	addr   string
+	auth   *Authenticator
+	server *http.Server
+
+	mu             sync.RWMutex
+	messageHandler func(ctx context.Context, message *transport.BaseJsonRpcMessage)
+	errorHandler   func(error)
+	closeHandler   func()
+	pending        map[transport.RequestId]pendingRequest
+	sessions       map[string]*sseSession
+	nextID         atomic.Int64
+	closing        chan struct{}
+}
+
+func newHTTPTransport(kind string, addr string, auth *Authenticator) *httpTransport {
+	t := &httpTransport{
+		kind:     kind,
+		addr:     addr,
+		auth:     auth,
+		pending:  make(map[transport.RequestId]pendingRequest),
+		sessions: make(map[string]*sseSession),
+		closing:  make(chan struct{}),
+	}
+
+	mux := http.NewServeMux()
+	if kind == TransportSSE {
+		mux.HandleFunc(sseEndpoint, t.handleSSE)
+		mux.HandleFunc(sseMessageEndpoint, t.handleSSEMessage)
+	} else {
+		mux.HandleFunc(httpEndpoint, t.handleHTTP)
+	}
+	t.server = &http.Server{
+		Addr:              addr,
+		Handler:           auth.Middleware(mux),
+		TLSConfig:         auth.TLSConfig(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	return t
+}
+
+// Start implements transport.Transport. It starts listening and serves requests in the background.
+func (t *httpTransport) Start(_ context.Context) error {
+	listener, err := net.Listen("tcp", t.addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", t.addr, err)
+	}
+	log.Printf("Serving MCP over %s on %s (tls: %t, authentication: %t)", t.kind, listener.Addr(), t.auth.TLS(), t.auth.Enabled())
+
+	go func() {
+		if t.auth.TLS() {
+			err = t.server.ServeTLS(listener, t.auth.certFile, t.auth.keyFile)
+		} else {
+			err = t.server.Serve(listener)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			t.handleError(fmt.Errorf("failed to serve: %w", err))
+		}
+	}()
+
+	return nil
+}
+
+// Close implements transport.Transport. It stops accepting new requests, ends all
+// event streams and waits for running requests to finish.
+func (t *httpTransport) Close() error {
+	close(t.closing)
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	err := t.server.Shutdown(ctx)
+
+	t.mu.RLock()
+	handler := t.closeHandler
+	t.mu.RUnlock()
+	if handler != nil {
+		handler()
+	}
+
+	return err
+}
+
+// Send implements transport.Transport. Responses are routed to the client which sent
+// the request, other messages from the server are sent to every event stream.
+func (t *httpTransport) Send(_ context.Context, message *transport.BaseJsonRpcMessage) error {
+	var id transport.RequestId
+	switch message.Type {
+	case transport.BaseMessageTypeJSONRPCResponseType:
+		id = message.JsonRpcResponse.Id
+	case transport.BaseMessageTypeJSONRPCErrorType:
+		id = message.JsonRpcError.Id
+	default:
+		t.broadcast(message)
+		return nil
+	}
+
+	t.mu.Lock()
+	request, ok := t.pending[id]
+	delete(t.pending, id)
+	t.mu.Unlock()
+	if !ok {
+		return fmt.Errorf("no pending request with id %d", id)
+	}
+
+	if message.Type == transport.BaseMessageTypeJSONRPCResponseType {
+		message.JsonRpcResponse.Id = request.id
+	} else {
+		message.JsonRpcError.Id = request.id
+	}
+	request.deliver(message)
+
+	return nil
+}
+
+// SetCloseHandler implements transport.Transport.
+func (t *httpTransport) SetCloseHandler(handler func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closeHandler = handler
+}
+
+// SetErrorHandler implements transport.Transport.
+func (t *httpTransport) SetErrorHandler(handler func(error)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.errorHandler = handler
+}
+
+// SetMessageHandler implements transport.Transport.
+func (t *httpTransport) SetMessageHandler(handler func(ctx context.Context, message *transport.BaseJsonRpcMessage)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.messageHandler = handler
+}
+
+func (t *httpTransport) handleError(err error) {
+	t.mu.RLock()
+	handler := t.errorHandler
+	t.mu.RUnlock()
+
+	if handler != nil {
+		handler(err)
+	} else {
+		log.Print(err)
+	}
+}
+
+// dispatch passes a message to the server. Requests get a unique ID and are remembered
+// until their response is sent. It reports whether a response is expected.
+func (t *httpTransport) dispatch(ctx context.Context, message *transport.BaseJsonRpcMessage, deliver func(*transport.BaseJsonRpcMessage)) bool {
+	t.mu.Lock()
+	handler := t.messageHandler
+	isRequest := message.Type == transport.BaseMessageTypeJSONRPCRequestType
+	if isRequest {
+		id := transport.RequestId(t.nextID.Add(1))
+		t.pending[id] = pendingRequest{id: message.JsonRpcRequest.Id, deliver: deliver}
+		message.JsonRpcRequest.Id = id
+	}
+	t.mu.Unlock()
+
+	if handler != nil {
+		handler(ctx, message)
+	}
+	return isRequest
+}
+
+func (t *httpTransport) broadcast(message *transport.BaseJsonRpcMessage) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	for _, session := range t.sessions {
+		select {
+		case session.events <- message:
+		case <-session.ctx.Done():
+		}
+	}
+}
+
+// readMessage reads a single JSON-RPC message from the body of a POST request.
+func readMessage(w http.ResponseWriter, r *http.Request) (*transport.BaseJsonRpcMessage, bool) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return nil, false
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize))
+	if err != nil {
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return nil, false
+	}
+
+	message, err := deserializeMessage(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, false
+	}
+
+	return message, true
+}
+
+// deserializeMessage deserializes a JSON-RPC request, notification, response or error.
+func deserializeMessage(body []byte) (*transport.BaseJsonRpcMessage, error) {
+	var request transport.BaseJSONRPCRequest
+	if err := json.Unmarshal(body, &request); err == nil {
+		return transport.NewBaseMessageRequest(&request), nil
+	}
+
+	var notification transport.BaseJSONRPCNotification
+	if err := json.Unmarshal(body, &notification); err == nil {
+		return transport.NewBaseMessageNotification(&notification), nil
+	}
+
+	var response transport.BaseJSONRPCResponse
+	if err := json.Unmarshal(body, &response); err == nil {
+		return transport.NewBaseMessageResponse(&response), nil
+	}
+
+	var errorResponse transport.BaseJSONRPCError
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		return transport.NewBaseMessageError(&errorResponse), nil
+	}
+
+	return nil, errors.New("failed to unmarshal JSON-RPC message, unrecognized type")
+}
+
+// handleHTTP handles a JSON-RPC message POSTed to the http transport.
+func (t *httpTransport) handleHTTP(w http.ResponseWriter, r *http.Request) {
+	message, ok := readMessage(w, r)
+	if !ok {
+		return
+	}
+
+	responses := make(chan *transport.BaseJsonRpcMessage, 1)
+	if !t.dispatch(r.Context(), message, func(response *transport.BaseJsonRpcMessage) { responses <- response }) {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	select {
+	case response := <-responses:
+		data, err := json.Marshal(response)
+		if err != nil {
+			http.Error(w, "failed to marshal response", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(data)
+	case <-r.Context().Done():
+	}
+}
+
+// handleSSE opens an event stream for a client of the sse transport. The first event
+// announces the endpoint the client has to POST its messages to.
+func (t *httpTransport) handleSSE(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	sessionID, err := newSessionID()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	session := &sseSession{ctx: ctx, events: make(chan *transport.BaseJsonRpcMessage), principal: principalFromContext(ctx)}
+
+	t.mu.Lock()
+	t.sessions[sessionID] = session
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.sessions, sessionID)
+		t.mu.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	_, _ = fmt.Fprintf(w, "event: endpoint\ndata: %s?sessionId=%s\n\n", sseMessageEndpoint, sessionID)
+	flusher.Flush()
+
+	for {
+		select {
+		case message := <-session.events:
+			data, err := json.Marshal(message)
+			if err != nil {
+				t.handleError(fmt.Errorf("failed to marshal message: %w", err))
+				continue
+			}
+			_, _ = fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+			flusher.Flush()
+		case <-ctx.Done():
+			return
+		case <-t.closing:
+			return
+		}
+	}
+}
+
+// handleSSEMessage handles a JSON-RPC message POSTed by a client of the sse transport.
+// The response is sent on the event stream of the client.
+func (t *httpTransport) handleSSEMessage(w http.ResponseWriter, r *http.Request) {
+	t.mu.RLock()
+	session, ok := t.sessions[r.URL.Query().Get("sessionId")]
+	t.mu.RUnlock()
+	if !ok {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+	// Only the client which opened the event stream may send messages to it
+	if principal := principalFromContext(r.Context()); principal != nil && (session.principal == nil || session.principal.Name != principal.Name) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	message, ok := readMessage(w, r)
+	if !ok {
+		return
+	}
+
+	// The request outlives the POST, it is bound to the event stream instead
+	t.dispatch(session.ctx, message, func(response *transport.BaseJsonRpcMessage) {
+		select {
+		case session.events <- response:
+		case <-session.ctx.Done():
+		}
+	})
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func newSessionID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return EmptyString, fmt.Errorf("failed to create session id: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -2,6 +2,9 @@ package main
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	mcpgolang "github.com/metoro-io/mcp-golang"
@@ -11,17 +14,275 @@ import (
 // It contains the Volume ID or Name that is needed to perform the lookup.
 type VolumeReadArgs struct {
 	IDOrName string `json:"id_or_name" jsonschema:"required,description=The volume id or name to be searched"`
+	ProjectArgs
+	OutputArgs
+}
+
+// VolumeListArgs represents the arguments for listing Volumes.
+// Volumes can be filtered by name and status on top of the label selector.
+type VolumeListArgs struct {
+	Name   string   `json:"name,omitempty" jsonschema:"description=Only return the volume with this name"`
+	Status []string `json:"status,omitempty" jsonschema:"description=Only return volumes with one of these statuses (creating/available)"`
+	ListArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// VolumeCreateArgs contains the necessary fields to create a new volume,
+// either in a location or attached to a server.
+type VolumeCreateArgs struct {
+	Name      string            `json:"name" jsonschema:"required,description=The volume name"`
+	Size      int               `json:"size" jsonschema:"required,description=Size of the volume in GB (10 to 10240)"`
+	Location  string            `json:"location,omitempty" jsonschema:"description=The location id or name to create the volume in (e.g. fsn1). Either location or server is required"`
+	Server    int64             `json:"server,omitempty" jsonschema:"description=The id of the server to attach the volume to. The volume is created in the location of the server"`
+	Format    string            `json:"format,omitempty" jsonschema:"description=Format the volume with this filesystem (xfs/ext4)"`
+	Automount *bool             `json:"automount,omitempty" jsonschema:"description=Mount the volume on the server after attaching it. Requires server"`
+	Labels    map[string]string `json:"labels,omitempty" jsonschema:"description=User-defined labels for the volume"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// VolumeActionArgs represents the arguments required to run an action on a Volume.
+// It contains the Volume ID the action is performed on.
+type VolumeActionArgs struct {
+	ID int64 `json:"id" jsonschema:"required,description=The volume id"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// VolumeResizeArgs represents the arguments required to resize a Volume.
+type VolumeResizeArgs struct {
+	ID   int64 `json:"id" jsonschema:"required,description=The volume id"`
+	Size int   `json:"size" jsonschema:"required,description=New size of the volume in GB. Volumes can only grow"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// VolumeAttachArgs represents the arguments required to attach a Volume to a Server.
+type VolumeAttachArgs struct {
+	ID        int64 `json:"id" jsonschema:"required,description=The volume id"`
+	Server    int64 `json:"server" jsonschema:"required,description=The id of the server to attach the volume to. It must be in the location of the volume"`
+	Automount *bool `json:"automount,omitempty" jsonschema:"description=Mount the volume on the server after attaching it"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// VolumeUpdateArgs represents the arguments required to rename a Volume or replace its labels.
+type VolumeUpdateArgs struct {
+	ID     int64             `json:"id" jsonschema:"required,description=The volume id"`
+	Name   string            `json:"name,omitempty" jsonschema:"description=New name of the volume"`
+	Labels map[string]string `json:"labels,omitempty" jsonschema:"description=New labels of the volume. Replaces all existing labels"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+// VolumeProtectionArgs represents the arguments required to change the protection of a Volume.
+type VolumeProtectionArgs struct {
+	ID     int64 `json:"id" jsonschema:"required,description=The volume id"`
+	Delete bool  `json:"delete" jsonschema:"required,description=Whether the volume is protected from deletion"`
+	WriteArgs
+	ProjectArgs
+	OutputArgs
+}
+
+type VolumeResponse struct {
+	ID          int64             `json:"id" jsonschema:"required,description=Unique identifier of the volume"`
+	Name        string            `json:"name" jsonschema:"required,description=The name of the volume"`
+	Status      string            `json:"status" jsonschema:"required,description=Status of the volume either of creating or available"`
+	Size        int               `json:"size" jsonschema:"required,description=Size of the volume in GB"`
+	Format      *string           `json:"format,omitempty" jsonschema:"description=Filesystem of the volume if it was formatted on creation"`
+	LinuxDevice string            `json:"linux_device" jsonschema:"description=Device path of the volume on the server it is attached to"`
+	Server      *ResourceRef      `json:"server" jsonschema:"description=The server the volume is attached to; null if it is detached"`
+	Location    *ResourceRef      `json:"location" jsonschema:"required,description=The location of the volume"`
+	Protection  DeleteProtection  `json:"protection" jsonschema:"required,description=Protection settings of the volume"`
+	Labels      map[string]string `json:"labels" jsonschema:"description=User-defined labels for the volume"`
+	Created     time.Time         `json:"created" jsonschema:"required,description=Timestamp of when the volume was created"`
+}
+
+func toVolumeResponse(v *hcloud.Volume) *VolumeResponse {
+	if v == nil {
+		return nil
+	}
+
+	return &VolumeResponse{
+		ID:          v.ID,
+		Name:        v.Name,
+		Status:      string(v.Status),
+		Size:        v.Size,
+		Format:      v.Format,
+		LinuxDevice: v.LinuxDevice,
+		Server:      toServerRef(v.Server),
+		Location:    toLocationRef(v.Location),
+		Protection:  DeleteProtection{Delete: v.Protection.Delete},
+		Labels:      v.Labels,
+		Created:     v.Created,
+	}
+}
+
+// Volumes are created and resized in steps of 1 GB within these limits.
+const (
+	minVolumeSize = 10
+	maxVolumeSize = 10240
+)
+
+// volumeFormats are the filesystems a volume can be formatted with on creation.
+var volumeFormats = []string{"xfs", "ext4"}
+
+type VolumeCreateResponse struct {
+	Volume      *VolumeResponse   `json:"volume" jsonschema:"required,description=The created volume"`
+	Action      *ActionResponse   `json:"action" jsonschema:"required,description=The action creating the volume"`
+	NextActions []*ActionResponse `json:"next_actions" jsonschema:"description=Actions which run after the volume is created (e.g. attaching it)"`
+}
+
+type VolumeActionResponse struct {
+	Volume *VolumeResponse `json:"volume" jsonschema:"description=The volume the action was performed on; null if it was deleted"`
+	Action *ActionResponse `json:"action" jsonschema:"description=The triggered action; null if the change did not need one"`
+}
+
+// getVolume retrieves a Volume by its ID and fails if the Volume does not exist.
+func getVolume(ctx context.Context, id int64) (*hcloud.Volume, error) {
+	volume, _, err := hcloudClient(ctx).Volume.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if volume == nil {
+		return nil, fmt.Errorf("volume %d not found", id)
+	}
+	return volume, nil
+}
+
+func checkVolumeSize(size int) error {
+	if size < minVolumeSize || size > maxVolumeSize {
+		return fmt.Errorf("invalid volume size %d GB, must be between %d and %d", size, minVolumeSize, maxVolumeSize)
+	}
+	return nil
+}
+
+func toVolumeCreateOpts(ctx context.Context, args VolumeCreateArgs) (hcloud.VolumeCreateOpts, error) {
+	opts := hcloud.VolumeCreateOpts{
+		Name:      args.Name,
+		Size:      args.Size,
+		Automount: args.Automount,
+	}
+	labels, err := scopeLabels(args.Labels)
+	if err != nil {
+		return opts, err
+	}
+	opts.Labels = labels
+
+	if err := checkVolumeSize(args.Size); err != nil {
+		return opts, err
+	}
+	if args.Format != EmptyString {
+		if !slices.Contains(volumeFormats, args.Format) {
+			return opts, fmt.Errorf("invalid volume format %q, must be one of xfs, ext4", args.Format)
+		}
+		opts.Format = hcloud.Ptr(args.Format)
+	}
+
+	switch {
+	case args.Server != 0 && args.Location != EmptyString:
+		return opts, fmt.Errorf("either location or server can be given, not both")
+	case args.Server != 0:
+		server, err := getServer(ctx, args.Server)
+		if err != nil {
+			return opts, err
+		}
+		if err := checkScope("server", server.ID, server.Labels); err != nil {
+			return opts, err
+		}
+		opts.Server = server
+	case args.Location != EmptyString:
+		location, _, err := hcloudClient(ctx).Location.Get(ctx, args.Location)
+		if err != nil {
+			return opts, err
+		}
+		if location == nil {
+			return opts, fmt.Errorf("location %s not found", args.Location)
+		}
+		opts.Location = location
+	default:
+		return opts, fmt.Errorf("either location or server is required")
+	}
+
+	return opts, opts.Validate()
+}
+
+// planVolumeCreate validates the options for creating a Volume and returns the planned change.
+func planVolumeCreate(ctx context.Context, opts hcloud.VolumeCreateOpts) (*PlannedChange, error) {
+	existing, _, err := hcloudClient(ctx).Volume.GetByName(ctx, opts.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("volume %s already exists", opts.Name)
+	}
+
+	location := opts.Location
+	if opts.Server != nil && opts.Server.Datacenter != nil {
+		location = opts.Server.Datacenter.Location
+	}
+	result := &VolumeCreateResponse{
+		Volume: &VolumeResponse{
+			Name:     opts.Name,
+			Status:   string(hcloud.VolumeStatusCreating),
+			Size:     opts.Size,
+			Format:   opts.Format,
+			Server:   toServerRef(opts.Server),
+			Location: toLocationRef(location),
+			Labels:   opts.Labels,
+			Created:  time.Now(),
+		},
+		Action:      plannedAction("create_volume"),
+		NextActions: []*ActionResponse{},
+	}
+	if opts.Server != nil {
+		result.NextActions = append(result.NextActions, plannedAction("attach_volume", ActionResource{ID: opts.Server.ID, Type: string(hcloud.ActionResourceTypeServer)}))
+	}
+
+	return newPlannedChange("create_volume", result), nil
+}
+
+// volumeTarget runs actions on Volumes with runAction.
+var volumeTarget = actionTarget[hcloud.Volume, VolumeResponse]{
+	name:       "volume",
+	actionType: string(hcloud.ActionResourceTypeVolume),
+	get:        getVolume,
+	labels:     func(volume *hcloud.Volume) map[string]string { return volume.Labels },
+	convert:    toVolumeResponse,
+	respond: func(volume *VolumeResponse, actions []*ActionResponse) any {
+		return &VolumeActionResponse{Volume: volume, Action: firstAction(actions)}
+	},
 }
 
 // VolumeTools
 var volumeTools = []Tool{
 	{
 		Name:        "get_all_volumes",
-		Description: "Returns all Volumes objects.",
-		Handler: func(_ NoArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() ([]*hcloud.Volume, error) {
-				result, err := client.Volume.All(context.Background())
-				return result, err
+		Description: "Returns a page of Volumes, optionally filtered by name, status and label selector.",
+		Handler: func(ctx context.Context, args VolumeListArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*ListResponse[*VolumeResponse], error) {
+				listOpts, err := args.listOpts()
+				if err != nil {
+					return nil, err
+				}
+				opts := hcloud.VolumeListOpts{
+					ListOpts: listOpts,
+					Name:     args.Name,
+				}
+				for _, status := range args.Status {
+					opts.Status = append(opts.Status, hcloud.VolumeStatus(status))
+				}
+				result, resp, err := hcloudClient(ctx).Volume.List(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				return newListResponse(toResponseList(result, toVolumeResponse), resp), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
@@ -29,12 +290,214 @@ var volumeTools = []Tool{
 	{
 		Name:        "get_a_volume_by_id_or_name",
 		Description: "Retrieves a Volume by its ID or Name. Get retrieves a volume by its ID if the input can be parsed as an integer, otherwise it retrieves a volume by its name. If the volume does not exist, nil is returned.",
-		Handler: func(args VolumeReadArgs) (*mcpgolang.ToolResponse, error) {
-			return handleResponse(func() (*hcloud.Volume, error) {
-				result, _, err := client.Volume.Get(context.Background(), args.IDOrName)
-				return result, err
+		Handler: func(ctx context.Context, args VolumeReadArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (*VolumeResponse, error) {
+				result, _, err := hcloudClient(ctx).Volume.Get(ctx, args.IDOrName)
+				if err != nil {
+					return nil, err
+				}
+				if result != nil && !visibleInScope(result.Labels) {
+					return nil, nil
+				}
+				return toVolumeResponse(result), nil
 			})
 		},
 		Restriction: RestrictionReadOnly,
 	},
+	{
+		Name:        "create_a_volume",
+		Description: "Creates a new Volume in a location or attached to a Server. Volumes can optionally be formatted and mounted automatically.",
+		Handler: func(ctx context.Context, args VolumeCreateArgs) (*mcpgolang.ToolResponse, error) {
+			return handleResponse(ctx, func() (any, error) {
+				opts, err := toVolumeCreateOpts(ctx, args)
+				if err != nil {
+					return nil, err
+				}
+				if args.isDryRun() {
+					return planVolumeCreate(ctx, opts)
+				}
+				result, _, err := hcloudClient(ctx).Volume.Create(ctx, opts)
+				if err != nil {
+					return nil, err
+				}
+				recordActions(ctx, append([]*hcloud.Action{result.Action}, result.NextActions...)...)
+				if args.Wait {
+					actions, err := waitForActions(ctx, append([]*hcloud.Action{result.Action}, result.NextActions...)...)
+					if err != nil {
+						return nil, err
+					}
+					result.Action, result.NextActions = actions[0], actions[1:]
+					result.Volume, _, err = hcloudClient(ctx).Volume.GetByID(ctx, result.Volume.ID)
+					if err != nil {
+						return nil, err
+					}
+				}
+				return &VolumeCreateResponse{
+					Volume:      toVolumeResponse(result.Volume),
+					Action:      toActionResponse(result.Action),
+					NextActions: toActionResponseList(result.NextActions),
+				}, nil
+			})
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "resize_a_volume",
+		Description: "Increases the size of a Volume. Volumes cannot be shrunk. The filesystem on the volume must be grown on the server afterwards.",
+		Handler: func(ctx context.Context, args VolumeResizeArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, volumeTarget, args.ID, args.WriteArgs, "resize_volume", false,
+				func(ctx context.Context, volume *hcloud.Volume) (*VolumeResponse, []string, error) {
+					if err := checkVolumeSize(args.Size); err != nil {
+						return nil, nil, err
+					}
+					if args.Size <= volume.Size {
+						return nil, nil, fmt.Errorf("volume %d has %d GB, it can only be resized to a larger size", volume.ID, volume.Size)
+					}
+					expected := toVolumeResponse(volume)
+					expected.Size = args.Size
+					return expected, nil, nil
+				},
+				singleAction(func(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Volume.Resize(ctx, volume, args.Size)
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "attach_a_volume",
+		Description: "Attaches a Volume to a Server in the same location. The volume must not be attached to another server.",
+		Handler: func(ctx context.Context, args VolumeAttachArgs) (*mcpgolang.ToolResponse, error) {
+			var server *hcloud.Server
+			return runAction(ctx, volumeTarget, args.ID, args.WriteArgs, "attach_volume", false,
+				func(ctx context.Context, volume *hcloud.Volume) (*VolumeResponse, []string, error) {
+					var err error
+					server, err = getServer(ctx, args.Server)
+					if err != nil {
+						return nil, nil, err
+					}
+					if err := checkScope("server", server.ID, server.Labels); err != nil {
+						return nil, nil, err
+					}
+					if volume.Server != nil {
+						if volume.Server.ID == server.ID {
+							return nil, nil, fmt.Errorf("volume %d is already attached to server %d", volume.ID, server.ID)
+						}
+						return nil, nil, fmt.Errorf("volume %d is attached to server %d, detach it first", volume.ID, volume.Server.ID)
+					}
+					if volume.Location != nil && server.Datacenter != nil && server.Datacenter.Location != nil &&
+						volume.Location.ID != server.Datacenter.Location.ID {
+						return nil, nil, fmt.Errorf("volume %d is in location %s, server %d is in location %s", volume.ID, volume.Location.Name, server.ID, server.Datacenter.Location.Name)
+					}
+					expected := toVolumeResponse(volume)
+					expected.Server = toServerRef(server)
+					return expected, nil, nil
+				},
+				singleAction(func(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Volume.AttachWithOpts(ctx, volume, hcloud.VolumeAttachOpts{
+						Server:    server,
+						Automount: args.Automount,
+					})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "detach_a_volume",
+		Description: "Detaches a Volume from the Server it is attached to. Unmount the volume on the server first to avoid data loss.",
+		Handler: func(ctx context.Context, args VolumeActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, volumeTarget, args.ID, args.WriteArgs, "detach_volume", false,
+				func(ctx context.Context, volume *hcloud.Volume) (*VolumeResponse, []string, error) {
+					if volume.Server == nil {
+						return nil, nil, fmt.Errorf("volume %d is not attached to a server", volume.ID)
+					}
+					expected := toVolumeResponse(volume)
+					expected.Server = nil
+					expected.LinuxDevice = EmptyString
+					return expected, nil, nil
+				},
+				singleAction(func(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Volume.Detach(ctx, volume)
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "update_a_volume",
+		Description: "Renames a Volume or replaces its labels.",
+		Handler: func(ctx context.Context, args VolumeUpdateArgs) (*mcpgolang.ToolResponse, error) {
+			var opts hcloud.VolumeUpdateOpts
+			return runAction(ctx, volumeTarget, args.ID, args.WriteArgs, "update_volume", true,
+				func(ctx context.Context, volume *hcloud.Volume) (*VolumeResponse, []string, error) {
+					if args.Name == EmptyString && args.Labels == nil {
+						return nil, nil, fmt.Errorf("either name or labels is required")
+					}
+					opts.Name = args.Name
+					expected := toVolumeResponse(volume)
+					if args.Name != EmptyString {
+						expected.Name = args.Name
+					}
+					if args.Labels != nil {
+						labels, err := scopeLabels(args.Labels)
+						if err != nil {
+							return nil, nil, err
+						}
+						opts.Labels = labels
+						expected.Labels = labels
+					}
+					return expected, nil, nil
+				},
+				singleAction(func(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, error) {
+					_, _, err := hcloudClient(ctx).Volume.Update(ctx, volume, opts)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "change_volume_protection",
+		Description: "Enables or disables the delete protection of a Volume.",
+		Handler: func(ctx context.Context, args VolumeProtectionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, volumeTarget, args.ID, args.WriteArgs, "change_protection", false,
+				func(ctx context.Context, volume *hcloud.Volume) (*VolumeResponse, []string, error) {
+					var warnings []string
+					if volume.Protection.Delete == args.Delete {
+						warnings = append(warnings, fmt.Sprintf("delete protection of volume %d is already %t", volume.ID, args.Delete))
+					}
+					expected := toVolumeResponse(volume)
+					expected.Protection.Delete = args.Delete
+					return expected, warnings, nil
+				},
+				singleAction(func(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, error) {
+					result, _, err := hcloudClient(ctx).Volume.ChangeProtection(ctx, volume, hcloud.VolumeChangeProtectionOpts{
+						Delete: hcloud.Ptr(args.Delete),
+					})
+					return result, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
+	{
+		Name:        "delete_a_volume",
+		Description: "Deletes a Volume. The volume must be detached and not protected. All data on the volume is lost, it cannot be undone.",
+		Handler: func(ctx context.Context, args VolumeActionArgs) (*mcpgolang.ToolResponse, error) {
+			return runAction(ctx, volumeTarget, args.ID, args.WriteArgs, "delete_volume", true,
+				func(ctx context.Context, volume *hcloud.Volume) (*VolumeResponse, []string, error) {
+					if volume.Protection.Delete {
+						return nil, nil, fmt.Errorf("volume %d is protected against deletion", volume.ID)
+					}
+					if volume.Server != nil {
+						return nil, nil, fmt.Errorf("volume %d is attached to server %d, detach it first", volume.ID, volume.Server.ID)
+					}
+					return nil, nil, nil
+				},
+				singleAction(func(ctx context.Context, volume *hcloud.Volume) (*hcloud.Action, error) {
+					_, err := hcloudClient(ctx).Volume.Delete(ctx, volume)
+					return nil, err
+				}))
+		},
+		Restriction: RestrictionReadWrite,
+	},
 }